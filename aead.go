@@ -0,0 +1,95 @@
+package camo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"runtime"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var errCiphertextTooShort = errors.New("camo: ciphertext too short")
+
+// SealAESGCM encrypts and authenticates plaintext and authenticates aad with
+// AES-GCM, using the secret as the key, which must be 16, 24 or 32 bytes
+// long. A random nonce is generated and prepended to the returned
+// ciphertext. It panics if the secret is zero.
+func (s Secret[O]) SealAESGCM(plaintext, aad []byte) ([]byte, error) {
+	aead, err := s.aead("SealAESGCM", newAESGCM)
+	if err != nil {
+		return nil, err
+	}
+	return seal(aead, plaintext, aad)
+}
+
+// OpenAESGCM decrypts and authenticates ciphertext produced by SealAESGCM and
+// authenticates aad, using the secret as the key. It panics if the secret is
+// zero.
+func (s Secret[O]) OpenAESGCM(ciphertext, aad []byte) ([]byte, error) {
+	aead, err := s.aead("OpenAESGCM", newAESGCM)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext, aad)
+}
+
+// SealChaCha20Poly1305 encrypts and authenticates plaintext and authenticates
+// aad with ChaCha20-Poly1305, using the secret as the key, which must be 32
+// bytes long. A random nonce is generated and prepended to the returned
+// ciphertext. It panics if the secret is zero.
+func (s Secret[O]) SealChaCha20Poly1305(plaintext, aad []byte) ([]byte, error) {
+	aead, err := s.aead("SealChaCha20Poly1305", chacha20poly1305.New)
+	if err != nil {
+		return nil, err
+	}
+	return seal(aead, plaintext, aad)
+}
+
+// OpenChaCha20Poly1305 decrypts and authenticates ciphertext produced by
+// SealChaCha20Poly1305 and authenticates aad, using the secret as the key. It
+// panics if the secret is zero.
+func (s Secret[O]) OpenChaCha20Poly1305(ciphertext, aad []byte) ([]byte, error) {
+	aead, err := s.aead("OpenChaCha20Poly1305", chacha20poly1305.New)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext, aad)
+}
+
+func (s Secret[O]) aead(method string, newAEAD func(key []byte) (cipher.AEAD, error)) (cipher.AEAD, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero(method)
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed(method)
+	// The constructors expand the key into their own state, so they can be
+	// given the content without copying it first.
+	return newAEAD(s.view())
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errCiphertextTooShort
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
@@ -0,0 +1,79 @@
+package camo
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAEAD(t *testing.T) {
+	key := Obscure(bytes.Repeat([]byte{7}, 32))
+	other := Obscure(bytes.Repeat([]byte{8}, 32))
+
+	cases := []struct {
+		name string
+		seal func(Secret[[]byte], []byte, []byte) ([]byte, error)
+		open func(Secret[[]byte], []byte, []byte) ([]byte, error)
+	}{
+		{"AESGCM", Secret[[]byte].SealAESGCM, Secret[[]byte].OpenAESGCM},
+		{"ChaCha20Poly1305", Secret[[]byte].SealChaCha20Poly1305, Secret[[]byte].OpenChaCha20Poly1305},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plaintext := []byte("attack at dawn")
+			aad := []byte("v1")
+
+			ct, err := tc.seal(key, plaintext, aad)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bytes.Contains(ct, plaintext) {
+				t.Errorf("ciphertext contains plaintext")
+			}
+			ct2, err := tc.seal(key, plaintext, aad)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bytes.Equal(ct, ct2) {
+				t.Errorf("expected random nonces to produce different ciphertexts")
+			}
+
+			got, err := tc.open(key, ct, aad)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("got = %q; want %q", got, plaintext)
+			}
+
+			if _, err := tc.open(key, ct, []byte("v2")); err == nil {
+				t.Errorf("expected error with wrong aad")
+			}
+			if _, err := tc.open(other, ct, aad); err == nil {
+				t.Errorf("expected error with wrong key")
+			}
+			if _, err := tc.open(key, ct[:4], aad); err == nil {
+				t.Errorf("expected error with truncated ciphertext")
+			}
+		})
+	}
+}
+
+func TestAEADInvalidKey(t *testing.T) {
+	key := Obscure([]byte("short"))
+	if _, err := key.SealAESGCM(nil, nil); err == nil {
+		t.Errorf("expected error for short AES key")
+	}
+	if _, err := key.SealChaCha20Poly1305(nil, nil); err == nil {
+		t.Errorf("expected error for short ChaCha20-Poly1305 key")
+	}
+}
+
+func TestPanicOnZeroAEAD(t *testing.T) {
+	var zero Secret[[]byte]
+	if _, ok := capturePanic(func() { zero.SealAESGCM(nil, nil) }); !ok {
+		t.Errorf("expected zero.SealAESGCM() to panic")
+	}
+	if _, ok := capturePanic(func() { zero.OpenChaCha20Poly1305(nil, nil) }); !ok {
+		t.Errorf("expected zero.OpenChaCha20Poly1305() to panic")
+	}
+}
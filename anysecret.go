@@ -0,0 +1,24 @@
+package camo
+
+// AnySecret is implemented by every instantiation of Secret, so that secrets
+// of different types can be held in a single collection, such as by
+// registries and keyrings. It can't be implemented outside of this package.
+// The typed Secret can be recovered with AsString or AsBytes.
+type AnySecret interface {
+	Valid() bool
+	Len() int
+	Label() string
+	Fingerprint() string
+	Hash64() uint64
+	String() string
+	AsString() Secret[string]
+	AsBytes() Secret[[]byte]
+
+	// str returns the content without copying it, for use by this package.
+	str() string
+}
+
+var (
+	_ AnySecret = Secret[string]{}
+	_ AnySecret = Secret[[]byte]{}
+)
@@ -0,0 +1,31 @@
+package camo
+
+import "testing"
+
+func TestAnySecret(t *testing.T) {
+	secrets := map[string]AnySecret{
+		"password": Obscure("hunter2", Labeled("password")),
+		"key":      Obscure([]byte{1, 2, 3}),
+	}
+	if s := secrets["password"]; !s.Valid() || s.Len() != 7 || s.Label() != "password" {
+		t.Errorf("got Valid() = %v, Len() = %d, Label() = %q", s.Valid(), s.Len(), s.Label())
+	}
+	if got := secrets["password"].String(); got != "camo.Secret[string](REDACTED)" {
+		t.Errorf("got = %q", got)
+	}
+	if got := secrets["password"].AsString(); got != Obscure("hunter2") {
+		t.Errorf("expected AsString() to recover the Secret")
+	}
+	if got := secrets["key"].AsBytes(); got != Obscure([]byte{1, 2, 3}) {
+		t.Errorf("expected AsBytes() to recover the Secret")
+	}
+	if secrets["key"].Fingerprint() != Obscure([]byte{1, 2, 3}).Fingerprint() {
+		t.Errorf("expected the same fingerprint through AnySecret")
+	}
+}
+
+func TestRegisterNil(t *testing.T) {
+	Register(nil)
+	Unregister(nil)
+	Register(Secret[string]{})
+}
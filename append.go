@@ -0,0 +1,75 @@
+package camo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// AppendBase64To appends the secret encoded with enc, such as
+// base64.StdEncoding, to dst, and returns the updated slice. It panics if the
+// secret is zero.
+func (s Secret[O]) AppendBase64To(dst []byte, enc *base64.Encoding) []byte {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("AppendBase64To")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("AppendBase64To")
+	return enc.AppendEncode(dst, s.view())
+}
+
+// AppendHexTo appends the secret encoded as lowercase hexadecimal to dst, and
+// returns the updated slice. It panics if the secret is zero.
+func (s Secret[O]) AppendHexTo(dst []byte) []byte {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("AppendHexTo")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("AppendHexTo")
+	return hex.AppendEncode(dst, s.view())
+}
+
+// AppendQuotedTo appends the secret as a double-quoted Go string literal, as
+// produced by strconv.Quote, to dst, and returns the updated slice. It is Go
+// syntax, which isn't safe to use in shell commands, as shells expand "$",
+// "`", and "\" within double quotes; use AppendShellQuotedTo for those. It
+// panics if the secret is zero.
+func (s Secret[O]) AppendQuotedTo(dst []byte) []byte {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("AppendQuotedTo")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("AppendQuotedTo")
+	return strconv.AppendQuote(dst, s.str())
+}
+
+// AppendShellQuotedTo appends the secret as a single-quoted POSIX shell word
+// to dst, and returns the updated slice. Each single quote in the secret is
+// written as '\'', so the shell takes the word as the content verbatim. It
+// panics if the secret is zero.
+func (s Secret[O]) AppendShellQuotedTo(dst []byte) []byte {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("AppendShellQuotedTo")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("AppendShellQuotedTo")
+	content := s.str()
+	dst = append(dst, '\'')
+	for {
+		i := strings.IndexByte(content, '\'')
+		if i < 0 {
+			break
+		}
+		dst = append(dst, content[:i]...)
+		dst = append(dst, `'\''`...)
+		content = content[i+1:]
+	}
+	dst = append(dst, content...)
+	return append(dst, '\'')
+}
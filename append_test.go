@@ -0,0 +1,47 @@
+package camo
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestAppendEncoded(t *testing.T) {
+	s := Obscure([]byte("user:p\"ss\n"))
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"base64", s.AppendBase64To([]byte("Basic "), base64.StdEncoding), "Basic dXNlcjpwInNzCg=="},
+		{"base64url", s.AppendBase64To(nil, base64.RawURLEncoding), "dXNlcjpwInNzCg"},
+		{"hex", s.AppendHexTo([]byte("0x")), "0x757365723a702273730a"},
+		{"quoted", s.AppendQuotedTo([]byte("pw=")), `pw="user:p\"ss\n"`},
+		{"shell", s.AppendShellQuotedTo([]byte("PW=")), "PW='user:p\"ss\n'"},
+		{"shell with quotes", Obscure("it's $HOME `id`").AppendShellQuotedTo(nil), `'it'\''s $HOME ` + "`id`'"},
+	}
+	for _, tc := range tests {
+		if string(tc.got) != tc.want {
+			t.Errorf("%s: got = %q; want %q", tc.name, tc.got, tc.want)
+		}
+	}
+
+	before := ReadStats().Appends
+	Obscure("x").AppendHexTo(nil)
+	if got := ReadStats().Appends - before; got != 1 {
+		t.Errorf("got %d appends counted; want 1", got)
+	}
+}
+
+func TestAppendEncodedZero(t *testing.T) {
+	var zero Secret[string]
+	for name, f := range map[string]func(){
+		"AppendBase64To":      func() { zero.AppendBase64To(nil, base64.StdEncoding) },
+		"AppendHexTo":         func() { zero.AppendHexTo(nil) },
+		"AppendQuotedTo":      func() { zero.AppendQuotedTo(nil) },
+		"AppendShellQuotedTo": func() { zero.AppendShellQuotedTo(nil) },
+	} {
+		if _, ok := capturePanic(f); !ok {
+			t.Errorf("expected zero.%s() to panic", name)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package camo
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// ByteArray is the set of fixed-size byte arrays commonly used as keys,
+// such as for AES-128, AES-256, and ChaCha20-Poly1305.
+type ByteArray interface {
+	~[16]byte | ~[24]byte | ~[32]byte | ~[48]byte | ~[64]byte
+}
+
+// arrayView returns the content of a as a byte slice without copying it.
+func arrayView[A ByteArray](a *A) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(a)), unsafe.Sizeof(*a))
+}
+
+// ObscureArray returns a Secret holding a copy of the content of the array a,
+// so that keys held in arrays don't have to be converted to slices first.
+// The copy of a that is passed in is wiped, but the caller's array isn't, so
+// the caller should clear it once it is no longer needed.
+func ObscureArray[A ByteArray](a A, opts ...Option) Secret[[]byte] {
+	buf := arrayView(&a)
+	defer wipe(buf)
+	return Obscure(buf, opts...)
+}
+
+// RevealArray returns the content of s as an array of type A, which must be
+// given explicitly, such as RevealArray[[32]byte](s). The boolean reports if
+// the length of the content matches the length of A, and the array is zero
+// if it doesn't. It panics if the secret is zero.
+func RevealArray[A ByteArray, O Obscurable](s Secret[O]) (A, bool) {
+	var a A
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("RevealArray")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("RevealArray")
+	dst := arrayView(&a)
+	if len(s.str()) != len(dst) {
+		return a, false
+	}
+	copy(dst, s.str())
+	return a, true
+}
@@ -0,0 +1,31 @@
+package camo
+
+import (
+	"crypto/sha256"
+	"testing"
+)
+
+func TestObscureArray(t *testing.T) {
+	key := sha256.Sum256([]byte("key"))
+	s := ObscureArray(key)
+	if s != Obscure(key[:]) {
+		t.Errorf("expected ObscureArray() to equal Obscure() of a slice of the array")
+	}
+
+	got, ok := RevealArray[[32]byte](s)
+	if !ok || got != key {
+		t.Errorf("got = %x, %v; want %x", got, ok, key)
+	}
+	type aesKey [16]byte
+	if got, ok := RevealArray[aesKey](s); ok || got != (aesKey{}) {
+		t.Errorf("expected a mismatched length to fail, got %x", got)
+	}
+	if got, ok := RevealArray[aesKey](Obscure("0123456789abcdef")); !ok || string(got[:]) != "0123456789abcdef" {
+		t.Errorf("got = %q, %v", got, ok)
+	}
+
+	var zero Secret[[]byte]
+	if _, ok := capturePanic(func() { RevealArray[[32]byte](zero) }); !ok {
+		t.Errorf("expected RevealArray() of a zero secret to panic")
+	}
+}
@@ -0,0 +1,104 @@
+package camo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+)
+
+// RevealInfo describes the extraction of the content of a Secret, as passed
+// to the reveal hook.
+type RevealInfo struct {
+	// Op is the name of the method that was called, such as "Reveal".
+	Op string
+
+	// Label is the label of the secret (see Labeled), if any.
+	Label string
+
+	// Function, File, and Line identify the caller of the method, which is
+	// the first function on the stack outside of this package, so that
+	// extractions through wrappers such as RateLimit are attributed to their
+	// caller. They are empty if the caller couldn't be determined.
+	Function string
+	File     string
+	Line     int
+}
+
+var revealHook atomic.Pointer[func(RevealInfo)]
+
+// SetRevealHook sets a process-wide hook that is called whenever the content
+// of a Secret is extracted, such as with Reveal or AppendTo, which makes it
+// possible to audit where plaintext is extracted at runtime. A nil hook
+// removes it.
+//
+// The hook is called synchronously by the goroutine extracting the content,
+// so it should return promptly, and must not extract the content of secrets
+// itself. Finding the caller has a cost, so it should be used with care in
+// performance-sensitive programs.
+func SetRevealHook(hook func(RevealInfo)) {
+	if hook == nil {
+		revealHook.Store(nil)
+		return
+	}
+	revealHook.Store(&hook)
+}
+
+// revealed enforces the caller policy of the secret, counts the extraction
+// in the Stats, and calls the reveal hook and the canary callback, if any.
+func (s Secret[O]) revealed(op string) {
+	s.box().revealed(op)
+}
+
+// revealed is like Secret.revealed, for the content of b.
+func (b *box) revealed(op string) {
+	if b.allowed != nil {
+		checkCaller(op, b)
+	}
+	countReveal(op)
+	hook := revealHook.Load()
+	if hook == nil && b.canary == nil {
+		return
+	}
+	info := RevealInfo{
+		Op:    op,
+		Label: b.label,
+	}
+	if f, ok := callerFrame(); ok {
+		info.Function, info.File, info.Line = f.Function, f.File, f.Line
+	}
+	if b.canary != nil {
+		b.canary(CanaryEvent{RevealInfo: info, Stack: debug.Stack()})
+	}
+	if hook != nil {
+		(*hook)(info)
+	}
+}
+
+// callerFrame returns the frame of the caller described by RevealInfo. The
+// tests of this package count as outside of it. It must be called directly
+// by box.revealed.
+func callerFrame() (runtime.Frame, bool) {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, callerFrame, and box.revealed.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if funcPackage(f.Function) != packagePath || strings.HasSuffix(f.File, "_test.go") {
+			return f, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// Label returns the label of the secret, as set with Labeled, or "" if it is
+// zero or has no label.
+func (s Secret[O]) Label() string {
+	if !s.Valid() {
+		return ""
+	}
+	return s.box().label
+}
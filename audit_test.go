@@ -0,0 +1,80 @@
+package camo
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRevealHook(t *testing.T) {
+	var infos []RevealInfo
+	SetRevealHook(func(info RevealInfo) { infos = append(infos, info) })
+	defer SetRevealHook(nil)
+
+	s := Obscure("hunter2", Labeled("db-password"))
+	s.Reveal()
+	s.AppendTo(nil)
+	Obscure([]byte("key")).Reveal()
+
+	if len(infos) != 3 {
+		t.Fatalf("hook called %d times; want 3", len(infos))
+	}
+	for i, op := range []string{"Reveal", "AppendTo", "Reveal"} {
+		info := infos[i]
+		if info.Op != op {
+			t.Errorf("infos[%d].Op = %q; want %q", i, info.Op, op)
+		}
+		if filepath.Base(info.File) != "audit_test.go" || info.Line == 0 {
+			t.Errorf("infos[%d] has caller %s:%d; want audit_test.go", i, info.File, info.Line)
+		}
+		if !strings.HasSuffix(info.Function, ".TestRevealHook") {
+			t.Errorf("infos[%d].Function = %q", i, info.Function)
+		}
+	}
+	if infos[0].Label != "db-password" || infos[2].Label != "" {
+		t.Errorf("labels = %q, %q; want %q, %q", infos[0].Label, infos[2].Label, "db-password", "")
+	}
+
+	SetRevealHook(nil)
+	s.Reveal()
+	if len(infos) != 3 {
+		t.Errorf("expected the hook to be removed")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	s := Obscure("hunter2", Labeled("db-password"))
+	if got := s.Label(); got != "db-password" {
+		t.Errorf("Label() = %q; want %q", got, "db-password")
+	}
+	if s != Obscure("hunter2") {
+		t.Errorf("expected labels not to affect comparisons")
+	}
+	if got := (Secret[string]{}).Label(); got != "" {
+		t.Errorf("Label() = %q for a zero secret", got)
+	}
+	if got := ObscureLocked("hunter2", Labeled("x")).Label(); got != "x" {
+		t.Errorf("Label() = %q for a locked secret; want %q", got, "x")
+	}
+}
+
+func TestRevealHookThroughWrapper(t *testing.T) {
+	var infos []RevealInfo
+	SetRevealHook(func(info RevealInfo) { infos = append(infos, info) })
+	defer SetRevealHook(nil)
+
+	pw := Obscure("hunter2")
+	RateLimit(pw, 1, 1).Reveal()
+	NewURL(&url.URL{Scheme: "postgres", Host: "db"}, "app", pw).Resolve()
+	Credentials{Username: "app", Password: pw}.Userinfo()
+
+	if len(infos) != 3 {
+		t.Fatalf("hook called %d times; want 3", len(infos))
+	}
+	for i, info := range infos {
+		if filepath.Base(info.File) != "audit_test.go" || !strings.HasSuffix(info.Function, ".TestRevealHookThroughWrapper") {
+			t.Errorf("infos[%d] has caller %s at %s:%d; want the test", i, info.Function, info.File, info.Line)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package camo
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCacheJitter is the fraction of the TTL by which refreshes are moved
+// earlier when Cache.Jitter is zero.
+const defaultCacheJitter = 0.1
+
+// Cache is a Provider that serves secrets resolved by another Provider from
+// memory, which makes remote providers usable on hot paths.
+//
+// Secrets are refreshed in the background shortly before their TTL expires,
+// with a random jitter so that secrets resolved at the same time aren't all
+// refreshed at once. If a refresh fails, the last value keeps being served
+// and the refresh is retried after another TTL. Secrets that aren't requested
+// for a whole TTL are dropped rather than refreshed.
+//
+// Errors are never cached, so a failed lookup is retried by the next call to
+// Get. Concurrent calls to Get for a name that isn't cached share a single
+// lookup, made with the context of the first of them. If that context is
+// done before the lookup is, the others make another lookup instead of
+// failing with its error.
+//
+// The zero value is not usable: Provider and TTL must be set. A Cache must
+// not be copied or modified after first use. It is safe for concurrent use.
+type Cache struct {
+	Provider Provider
+	TTL      time.Duration
+
+	// Jitter is the largest fraction of TTL by which a refresh is moved
+	// earlier. It defaults to 0.1.
+	Jitter float64
+
+	// OnRefreshError is called when refreshing a secret in the background
+	// fails.
+	OnRefreshError func(name string, err error)
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	ctx     context.Context
+	cancel  context.CancelFunc
+	closed  bool
+}
+
+type cacheEntry struct {
+	// ready is closed once the initial lookup is done, after which err is
+	// set if it failed, and abandoned is set if it failed because the
+	// context it was made with is done.
+	ready     chan struct{}
+	err       error
+	abandoned bool
+
+	value atomic.Pointer[Secret[string]]
+
+	// used is set when the entry is read, and cleared when it is refreshed.
+	used  atomic.Bool
+	timer *time.Timer
+}
+
+// Get returns the cached secret for name, resolving it with Provider if it
+// isn't cached. After Close, Get calls Provider directly.
+func (c *Cache) Get(ctx context.Context, name string) (Secret[string], error) {
+	for {
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			return c.Provider.Get(ctx, name)
+		}
+		if c.entries == nil {
+			c.entries = make(map[string]*cacheEntry)
+			c.ctx, c.cancel = context.WithCancel(context.Background())
+		}
+		e, ok := c.entries[name]
+		if !ok {
+			e = &cacheEntry{ready: make(chan struct{})}
+			c.entries[name] = e
+		}
+		c.mu.Unlock()
+
+		if !ok {
+			c.fill(ctx, name, e)
+		}
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return Secret[string]{}, ctx.Err()
+		}
+		if e.abandoned {
+			if err := ctx.Err(); err != nil {
+				return Secret[string]{}, err
+			}
+			// The lookup was made with the context of another call.
+			continue
+		}
+		if e.err != nil {
+			return Secret[string]{}, e.err
+		}
+		e.used.Store(true)
+		return *e.value.Load(), nil
+	}
+}
+
+// Close stops refreshing secrets and drops them from the cache.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	for _, e := range c.entries {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+	}
+	c.entries = nil
+	if c.cancel != nil {
+		c.cancel()
+	}
+	return nil
+}
+
+// fill does the initial lookup for e.
+func (c *Cache) fill(ctx context.Context, name string, e *cacheEntry) {
+	s, err := c.Provider.Get(ctx, name)
+	if err != nil {
+		e.err = err
+		e.abandoned = ctx.Err() != nil
+		c.mu.Lock()
+		c.remove(name, e)
+		c.mu.Unlock()
+		close(e.ready)
+		return
+	}
+	e.value.Store(&s)
+	close(e.ready)
+	c.schedule(name, e)
+}
+
+// refresh looks up the secret for e again, unless it hasn't been used since
+// it was last refreshed.
+func (c *Cache) refresh(name string, e *cacheEntry) {
+	if !e.used.Swap(false) {
+		c.mu.Lock()
+		c.remove(name, e)
+		c.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, c.TTL)
+	s, err := c.Provider.Get(ctx, name)
+	cancel()
+	if err != nil {
+		if c.OnRefreshError != nil && c.ctx.Err() == nil {
+			c.OnRefreshError(name, err)
+		}
+	} else {
+		e.value.Store(&s)
+	}
+	c.schedule(name, e)
+}
+
+// schedule arranges for e to be refreshed, unless it has been removed.
+func (c *Cache) schedule(name string, e *cacheEntry) {
+	jitter := c.Jitter
+	if jitter == 0 {
+		jitter = defaultCacheJitter
+	}
+	d := c.TTL
+	if n := int64(float64(d) * jitter); n > 0 {
+		d -= time.Duration(rand.Int64N(n))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed && c.entries[name] == e {
+		e.timer = time.AfterFunc(d, func() { c.refresh(name, e) })
+	}
+}
+
+// remove drops e from the cache if it is still the entry for name. c.mu must
+// be held.
+func (c *Cache) remove(name string, e *cacheEntry) {
+	if c.entries[name] == e {
+		delete(c.entries, name)
+	}
+}
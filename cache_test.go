@@ -0,0 +1,156 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	var (
+		calls atomic.Int32
+		fail  atomic.Bool
+	)
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		if name == "missing" {
+			return Secret[string]{}, notFound(name)
+		}
+		n := calls.Add(1)
+		if fail.Load() && n > 1 {
+			return Secret[string]{}, errors.New("unavailable")
+		}
+		return Obscure(name + string(rune('0'+n))), nil
+	})
+	refreshErrs := make(chan error, 100)
+	c := &Cache{
+		Provider:       p,
+		TTL:            20 * time.Millisecond,
+		OnRefreshError: func(name string, err error) { refreshErrs <- err },
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	s, err := c.Get(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "db1" {
+		t.Fatalf("Reveal() = %q; want %q", got, "db1")
+	}
+	if s2, _ := c.Get(ctx, "db"); s2 != s || calls.Load() != 1 {
+		t.Errorf("expected the cached secret to be served")
+	}
+
+	waitFor(t, func() bool {
+		s, _ := c.Get(ctx, "db")
+		return s != Obscure("db1")
+	})
+
+	fail.Store(true)
+	before, _ := c.Get(ctx, "db")
+	select {
+	case <-refreshErrs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a refresh error")
+	}
+	if s, _ := c.Get(ctx, "db"); s != before {
+		t.Errorf("expected the stale secret to be served")
+	}
+
+	if _, err := c.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestCacheSharesLookup(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		calls.Add(1)
+		<-release
+		return Obscure("hunter2"), nil
+	})
+	c := &Cache{Provider: p, TTL: time.Hour}
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get(context.Background(), "db"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Provider called %d times; want 1", n)
+	}
+}
+
+func TestCacheAbandonedLookup(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return Secret[string]{}, ctx.Err()
+		}
+		return Obscure("hunter2"), nil
+	})
+	c := &Cache{Provider: p, TTL: time.Hour}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := c.Get(ctx, "db")
+		errs <- err
+	}()
+	<-started
+	type result struct {
+		s   Secret[string]
+		err error
+	}
+	results := make(chan result)
+	go func() {
+		s, err := c.Get(context.Background(), "db")
+		results <- result{s, err}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled Get err = %v; want context.Canceled", err)
+	}
+	if r := <-results; r.err != nil || r.s != Obscure("hunter2") {
+		t.Errorf("other Get = %v, %v; want the secret", r.s, r.err)
+	}
+}
+
+func TestCacheDropsUnused(t *testing.T) {
+	var calls atomic.Int32
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		calls.Add(1)
+		return Obscure("hunter2"), nil
+	})
+	c := &Cache{Provider: p, TTL: time.Millisecond}
+	defer c.Close()
+	if _, err := c.Get(context.Background(), "db"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.entries) == 0
+	})
+	if n := calls.Load(); n > 2 {
+		t.Errorf("Provider called %d times; want no more than 2", n)
+	}
+}
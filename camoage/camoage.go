@@ -0,0 +1,69 @@
+// Package camoage encrypts camo Secrets in the age format for storage at
+// rest, and decrypts them back into Secrets, with the identity used for
+// decryption also held in a Secret.
+package camoage
+
+import (
+	"bytes"
+	"io"
+
+	"filippo.io/age"
+	"github.com/rbranson/camo"
+)
+
+// Encrypt encrypts the content of s to the given recipients, returning the
+// binary age ciphertext. The revealed copy of the content is wiped once
+// encrypted.
+func Encrypt[O camo.Obscurable](s camo.Secret[O], recipients ...age.Recipient) ([]byte, error) {
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := s.AppendTo(nil)
+	defer clear(plaintext)
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Decrypt decrypts age ciphertext with the identities in identity, which is
+// in the format of an age identity file, such as the output of age-keygen.
+// The revealed copy of the identity and the intermediate buffers holding the
+// plaintext are wiped.
+func Decrypt[O camo.Obscurable](ciphertext []byte, identity camo.Secret[O]) (camo.Secret[[]byte], error) {
+	identities, err := ParseIdentities(identity)
+	if err != nil {
+		return camo.Secret[[]byte]{}, err
+	}
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), identities...)
+	if err != nil {
+		return camo.Secret[[]byte]{}, err
+	}
+	// The plaintext is read in one go into a buffer that is big enough, as
+	// age ciphertext is always larger than its plaintext, so that no
+	// outgrown buffers are left behind.
+	buf := make([]byte, len(ciphertext)+1)
+	defer clear(buf)
+	n, err := io.ReadFull(r, buf)
+	if err != io.EOF && err != io.ErrUnexpectedEOF {
+		if err == nil {
+			err = io.ErrShortBuffer
+		}
+		return camo.Secret[[]byte]{}, err
+	}
+	return camo.Obscure(buf[:n]), nil
+}
+
+// ParseIdentities parses the identities in identity, which is in the format
+// of an age identity file. The revealed copy of the identity is wiped once
+// parsed.
+func ParseIdentities[O camo.Obscurable](identity camo.Secret[O]) ([]age.Identity, error) {
+	buf := identity.AppendTo(nil)
+	defer clear(buf)
+	return age.ParseIdentities(bytes.NewReader(buf))
+}
@@ -0,0 +1,49 @@
+package camoage
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/rbranson/camo"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := camo.Obscure("# created: now\n" + id.String() + "\n")
+
+	for _, content := range []string{"", "hunter2", string(bytes.Repeat([]byte("x"), 200000))} {
+		ciphertext, err := Encrypt(camo.Obscure(content), id.Recipient())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(content) > 0 && bytes.Contains(ciphertext, []byte(content)) {
+			t.Errorf("ciphertext contains the plaintext")
+		}
+		s, err := Decrypt(ciphertext, identity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Reveal(); string(got) != content {
+			t.Errorf("Decrypt() returned %d bytes; want %d", len(got), len(content))
+		}
+	}
+}
+
+func TestDecryptWrongIdentity(t *testing.T) {
+	id, _ := age.GenerateX25519Identity()
+	other, _ := age.GenerateX25519Identity()
+	ciphertext, err := Encrypt(camo.Obscure([]byte("hunter2")), id.Recipient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(ciphertext, camo.Obscure(other.String())); err == nil {
+		t.Errorf("expected an error when decrypting with the wrong identity")
+	}
+	if _, err := Decrypt(ciphertext, camo.Obscure("not an identity")); err == nil {
+		t.Errorf("expected an error for an invalid identity")
+	}
+}
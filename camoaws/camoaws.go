@@ -0,0 +1,116 @@
+// Package camoaws provides camo.Providers that read secrets from AWS Secrets
+// Manager and from SecureString parameters in SSM Parameter Store.
+package camoaws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/rbranson/camo"
+)
+
+// SecretsManagerClient is the subset of *secretsmanager.Client used by
+// SecretsManager.
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// SecretsManager is a camo.Provider that reads secrets from AWS Secrets
+// Manager. Names are secret names or ARNs, optionally followed by "#key" to
+// select a key from a secret that holds a JSON object, such as
+// "prod/db#password".
+type SecretsManager struct {
+	Client SecretsManagerClient
+
+	// VersionStage selects the version of each secret to read, such as
+	// "AWSPREVIOUS" or "AWSPENDING" during rotation. It defaults to
+	// "AWSCURRENT".
+	VersionStage string
+}
+
+// Get reads the secret with the given name from Secrets Manager.
+func (p SecretsManager) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	id, key, hasKey := strings.Cut(name, "#")
+	in := &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)}
+	if p.VersionStage != "" {
+		in.VersionStage = aws.String(p.VersionStage)
+	}
+	out, err := p.Client.GetSecretValue(ctx, in)
+	if notFound := (*smtypes.ResourceNotFoundException)(nil); errors.As(err, &notFound) {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	var value string
+	switch {
+	case out.SecretString != nil:
+		value = *out.SecretString
+	case out.SecretBinary != nil:
+		value = string(out.SecretBinary)
+		clear(out.SecretBinary)
+	}
+	if !hasKey {
+		return camo.Obscure(value), nil
+	}
+	return jsonKey(name, value, key)
+}
+
+// jsonKey returns the string value of key in the JSON object doc.
+func jsonKey(name, doc, key string) (camo.Secret[string], error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(doc), &fields); err != nil {
+		// The error may quote the document.
+		return camo.Secret[string]{}, fmt.Errorf("camoaws: %q is not a JSON object", name)
+	}
+	value, ok := fields[key]
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("camoaws: key %q of %q is a %T, not a string", key, name, value)
+	}
+	return camo.Obscure(str), nil
+}
+
+// ParameterStoreClient is the subset of *ssm.Client used by ParameterStore.
+type ParameterStoreClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// ParameterStore is a camo.Provider that reads parameters from SSM Parameter
+// Store, decrypting SecureString parameters. Names are parameter names or
+// ARNs, and may select a version or label with a suffix such as ":3" or
+// ":prod".
+type ParameterStore struct {
+	Client ParameterStoreClient
+}
+
+// Get reads the parameter with the given name from Parameter Store.
+func (p ParameterStore) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	out, err := p.Client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	var notFound *ssmtypes.ParameterNotFound
+	var versionNotFound *ssmtypes.ParameterVersionNotFound
+	if errors.As(err, &notFound) || errors.As(err, &versionNotFound) {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return camo.Secret[string]{}, fmt.Errorf("camoaws: parameter %q has no value", name)
+	}
+	return camo.Obscure(*out.Parameter.Value), nil
+}
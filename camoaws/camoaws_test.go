@@ -0,0 +1,107 @@
+package camoaws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/rbranson/camo"
+)
+
+type fakeSecretsManager map[string]string
+
+func (f fakeSecretsManager) GetSecretValue(ctx context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	stage := "AWSCURRENT"
+	if in.VersionStage != nil {
+		stage = *in.VersionStage
+	}
+	value, ok := f[*in.SecretId+"/"+stage]
+	if !ok {
+		return nil, &smtypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(value)}, nil
+}
+
+func TestSecretsManager(t *testing.T) {
+	client := fakeSecretsManager{
+		"api-key/AWSCURRENT":  "new",
+		"api-key/AWSPREVIOUS": "old",
+		"db/AWSCURRENT":       `{"username":"admin","password":"hunter2","port":5432}`,
+	}
+	ctx := context.Background()
+	p := SecretsManager{Client: client}
+
+	tests := map[string]string{
+		"api-key":     "new",
+		"db#password": "hunter2",
+	}
+	for name, want := range tests {
+		s, err := p.Get(ctx, name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != want {
+			t.Errorf("Get(%q) = %q; want %q", name, got, want)
+		}
+	}
+
+	for _, name := range []string{"missing", "db#missing"} {
+		if _, err := p.Get(ctx, name); !errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want camo.ErrNotFound", name, err)
+		}
+	}
+	if _, err := p.Get(ctx, "db#port"); err == nil || errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want an error for a non-string key", err)
+	}
+	if _, err := p.Get(ctx, "api-key#key"); err == nil || errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want an error for a secret that isn't JSON", err)
+	}
+
+	p.VersionStage = "AWSPREVIOUS"
+	s, err := p.Get(ctx, "api-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "old" {
+		t.Errorf("Reveal() = %q; want %q", got, "old")
+	}
+}
+
+type fakeParameterStore map[string]string
+
+func (f fakeParameterStore) GetParameter(ctx context.Context, in *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	if in.WithDecryption == nil || !*in.WithDecryption {
+		return nil, errors.New("expected decryption to be requested")
+	}
+	value, ok := f[*in.Name]
+	if !ok {
+		return nil, &ssmtypes.ParameterNotFound{Message: aws.String("not found")}
+	}
+	return &ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Value: aws.String(value)}}, nil
+}
+
+func TestParameterStore(t *testing.T) {
+	p := ParameterStore{Client: fakeParameterStore{"/prod/db/password": "hunter2"}}
+	s, err := p.Get(context.Background(), "/prod/db/password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if _, err := p.Get(context.Background(), "/missing"); !errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want camo.ErrNotFound", err)
+	}
+}
+
+var (
+	_ camo.Provider        = SecretsManager{}
+	_ camo.Provider        = ParameterStore{}
+	_ SecretsManagerClient = (*secretsmanager.Client)(nil)
+	_ ParameterStoreClient = (*ssm.Client)(nil)
+)
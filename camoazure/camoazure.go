@@ -0,0 +1,128 @@
+// Package camoazure provides a camo.Provider that reads secrets from Azure
+// Key Vault, along with a managed identity credential for authenticating
+// from Azure VMs, App Service, and AKS nodes.
+package camoazure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/rbranson/camo"
+)
+
+// Provider is a camo.Provider that reads secrets from Key Vault. Names are
+// secret names, such as "db-password", which read the latest version, or
+// "db-password@<version>" to pin a version.
+type Provider struct {
+	client *azsecrets.Client
+}
+
+// New returns a Provider for the vault at vaultURL, such as
+// "https://myvault.vault.azure.net". If cred is nil, a
+// ManagedIdentityCredential for the system-assigned identity is used. Any
+// azcore.TokenCredential, such as those in the azidentity package, can be
+// used instead.
+func New(vaultURL string, cred azcore.TokenCredential, options *azsecrets.ClientOptions) (*Provider, error) {
+	if cred == nil {
+		cred = &ManagedIdentityCredential{}
+	}
+	client, err := azsecrets.NewClient(vaultURL, cred, options)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{client: client}, nil
+}
+
+// Get reads the secret with the given name from Key Vault.
+func (p *Provider) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	secretName, version, _ := strings.Cut(name, "@")
+	resp, err := p.client.GetSecret(ctx, secretName, version, nil)
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	if resp.Value == nil {
+		return camo.Secret[string]{}, fmt.Errorf("camoazure: secret %q has no value", name)
+	}
+	return camo.Obscure(*resp.Value), nil
+}
+
+const imdsEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// ManagedIdentityCredential is an azcore.TokenCredential that gets tokens for
+// the managed identity of the Azure resource it runs on. It uses the
+// IDENTITY_ENDPOINT and IDENTITY_HEADER environment variables when they are
+// set, as they are on App Service and Azure Functions, and the Instance
+// Metadata Service otherwise, as on VMs and AKS nodes.
+type ManagedIdentityCredential struct {
+	// ClientID selects a user-assigned identity. If empty, the
+	// system-assigned identity is used.
+	ClientID string
+
+	// Client makes the token requests. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// GetToken requests a token for the scope in opts.
+func (c *ManagedIdentityCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if len(opts.Scopes) != 1 {
+		return azcore.AccessToken{}, fmt.Errorf("camoazure: managed identity tokens require exactly one scope, got %d", len(opts.Scopes))
+	}
+	resource := strings.TrimSuffix(opts.Scopes[0], "/.default")
+
+	endpoint, apiVersion, header, headerValue := imdsEndpoint, "2018-02-01", "Metadata", "true"
+	if e, h := os.Getenv("IDENTITY_ENDPOINT"), os.Getenv("IDENTITY_HEADER"); e != "" && h != "" {
+		endpoint, apiVersion, header, headerValue = e, "2019-08-01", "X-IDENTITY-HEADER", h
+	}
+	q := url.Values{"api-version": {apiVersion}, "resource": {resource}}
+	if c.ClientID != "" {
+		q.Set("client_id", c.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+	req.Header.Set(header, headerValue)
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return azcore.AccessToken{}, fmt.Errorf("camoazure: requesting managed identity token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return azcore.AccessToken{}, fmt.Errorf("camoazure: requesting managed identity token: %s: %s", resp.Status, body)
+	}
+	var token struct {
+		AccessToken string      `json:"access_token"`
+		ExpiresOn   json.Number `json:"expires_on"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return azcore.AccessToken{}, fmt.Errorf("camoazure: decoding managed identity token: %w", err)
+	}
+	expiresOn, err := strconv.ParseInt(token.ExpiresOn.String(), 10, 64)
+	if err != nil {
+		return azcore.AccessToken{}, fmt.Errorf("camoazure: invalid managed identity token expiry %q", token.ExpiresOn)
+	}
+	return azcore.AccessToken{Token: token.AccessToken, ExpiresOn: time.Unix(expiresOn, 0)}, nil
+}
+
+var _ camo.Provider = (*Provider)(nil)
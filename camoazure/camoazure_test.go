@@ -0,0 +1,102 @@
+package camoazure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/rbranson/camo"
+)
+
+type staticCredential string
+
+func (c staticCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: string(c), ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// fakeVault is a policy.Transporter serving a Key Vault secrets API.
+type fakeVault map[string]string
+
+func (f fakeVault) Do(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{Request: req, Header: http.Header{"Content-Type": {"application/json"}}}
+	if req.Header.Get("Authorization") != "Bearer token" {
+		resp.StatusCode = http.StatusUnauthorized
+		resp.Header.Set("WWW-Authenticate", `Bearer authorization="https://login.microsoftonline.com/tenant", resource="https://vault.azure.net"`)
+		resp.Body = io.NopCloser(strings.NewReader(""))
+		return resp, nil
+	}
+	value, ok := f[req.URL.Path]
+	if !ok {
+		resp.StatusCode = http.StatusNotFound
+		resp.Body = io.NopCloser(strings.NewReader(`{"error":{"code":"SecretNotFound","message":"not found"}}`))
+		return resp, nil
+	}
+	body, _ := json.Marshal(map[string]any{"value": value, "id": "https://fake.vault.azure.net" + req.URL.Path})
+	resp.StatusCode = http.StatusOK
+	resp.Body = io.NopCloser(strings.NewReader(string(body)))
+	return resp, nil
+}
+
+func TestGet(t *testing.T) {
+	p, err := New("https://fake.vault.azure.net", staticCredential("token"), &azsecrets.ClientOptions{
+		ClientOptions: policy.ClientOptions{Transport: fakeVault{
+			"/secrets/db-password/":   "new",
+			"/secrets/db-password/v1": "old",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	tests := map[string]string{
+		"db-password":    "new",
+		"db-password@v1": "old",
+	}
+	for name, want := range tests {
+		s, err := p.Get(ctx, name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != want {
+			t.Errorf("Get(%q) = %q; want %q", name, got, want)
+		}
+	}
+	if _, err := p.Get(ctx, "missing"); !errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want camo.ErrNotFound", err)
+	}
+}
+
+func TestManagedIdentityCredential(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-IDENTITY-HEADER") != "header" {
+			http.Error(w, "missing header", http.StatusBadRequest)
+			return
+		}
+		if got := r.URL.Query().Get("resource"); got != "https://vault.azure.net" {
+			http.Error(w, "unexpected resource "+got, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"access_token":"token","expires_on":"1700000000"}`))
+	}))
+	defer srv.Close()
+	t.Setenv("IDENTITY_ENDPOINT", srv.URL)
+	t.Setenv("IDENTITY_HEADER", "header")
+
+	c := &ManagedIdentityCredential{}
+	tok, err := c.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://vault.azure.net/.default"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Token != "token" || !tok.ExpiresOn.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("GetToken() = %+v", tok)
+	}
+}
@@ -0,0 +1,305 @@
+// Package camocheck defines an analyzer that reports common misuses of camo
+// Secrets:
+//
+//   - calling Reveal or AppendTo on a Secret that is always zero, which
+//     panics;
+//   - storing the result of Reveal in a struct field or a package-level
+//     variable, where the plaintext outlives the code that needed it;
+//   - calling Reveal while initializing a package, which keeps the
+//     plaintext around for the life of the program.
+//
+// It also defines LeakAnalyzer, which reports revealed Secrets that are
+// passed to logging and printing functions.
+//
+// The analyzers can be run with go vet:
+//
+//	go install github.com/rbranson/camo/camocheck/cmd/camocheck@latest
+//	go vet -vettool=$(which camocheck) ./...
+package camocheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// camoPath is the import path of the camo package.
+const camoPath = "github.com/rbranson/camo"
+
+// Analyzer reports misuses of camo Secrets.
+var Analyzer = &analysis.Analyzer{
+	Name:     "camocheck",
+	Doc:      "report misuses of camo Secrets",
+	URL:      "https://pkg.go.dev/github.com/rbranson/camo/camocheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				checkPackageVars(pass, decl)
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.Name == "init" && decl.Body != nil {
+					reportInitReveals(pass, decl.Body)
+				}
+			}
+		}
+	}
+
+	nodes := []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	insp.Preorder(nodes, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body != nil {
+			checkZeroReveals(pass, body)
+		}
+	})
+
+	insp.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		checkStoredReveals(pass, n.(*ast.AssignStmt))
+	})
+	insp.Preorder([]ast.Node{(*ast.CompositeLit)(nil)}, func(n ast.Node) {
+		checkRevealsInStructLit(pass, n.(*ast.CompositeLit))
+	})
+	return nil, nil
+}
+
+// checkPackageVars reports calls to Reveal in the initializers of
+// package-level variables.
+func checkPackageVars(pass *analysis.Pass, decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for _, v := range vs.Values {
+			reportInitReveals(pass, v)
+		}
+	}
+}
+
+// reportInitReveals reports calls to Reveal in n, which runs while the
+// package is initialized.
+func reportInitReveals(pass *analysis.Pass, n ast.Node) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			// Function literals don't necessarily run during
+			// initialization.
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok && isSecretMethod(pass.TypesInfo, call, "Reveal") {
+			pass.ReportRangef(call, "Secret revealed during package initialization; reveal it where it is used instead")
+		}
+		return true
+	})
+}
+
+// checkStoredReveals reports the results of Reveal being assigned to struct
+// fields or package-level variables.
+func checkStoredReveals(pass *analysis.Pass, assign *ast.AssignStmt) {
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return
+	}
+	for i, rhs := range assign.Rhs {
+		call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+		if !ok || !isSecretMethod(pass.TypesInfo, call, "Reveal") {
+			continue
+		}
+		if where := storage(pass.TypesInfo, assign.Lhs[i]); where != "" {
+			pass.ReportRangef(assign, "revealed Secret stored in %s; keep the Secret and reveal it where it is used instead", where)
+		}
+	}
+}
+
+// storage describes where an assignment to lhs stores its value, if it is a
+// struct field or a package-level variable, or returns "".
+func storage(info *types.Info, lhs ast.Expr) string {
+	switch lhs := ast.Unparen(lhs).(type) {
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[lhs]; ok && sel.Kind() == types.FieldVal {
+			return "struct field " + lhs.Sel.Name
+		}
+		// A qualified identifier, such as pkg.Var.
+		if v, ok := info.Uses[lhs.Sel].(*types.Var); ok && isPackageLevel(v) {
+			return "package-level variable " + lhs.Sel.Name
+		}
+	case *ast.Ident:
+		if v, ok := info.ObjectOf(lhs).(*types.Var); ok && isPackageLevel(v) {
+			return "package-level variable " + lhs.Name
+		}
+	}
+	return ""
+}
+
+func isPackageLevel(v *types.Var) bool {
+	return v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// checkRevealsInStructLit reports the results of Reveal being used as the
+// values of fields in struct literals.
+func checkRevealsInStructLit(pass *analysis.Pass, lit *ast.CompositeLit) {
+	tv, ok := pass.TypesInfo.Types[lit]
+	if !ok {
+		return
+	}
+	if _, ok := tv.Type.Underlying().(*types.Struct); !ok {
+		return
+	}
+	for _, elt := range lit.Elts {
+		value, name := elt, ""
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			value = kv.Value
+			if id, ok := kv.Key.(*ast.Ident); ok {
+				name = id.Name
+			}
+		}
+		call, ok := ast.Unparen(value).(*ast.CallExpr)
+		if !ok || !isSecretMethod(pass.TypesInfo, call, "Reveal") {
+			continue
+		}
+		where := "struct field"
+		if name != "" {
+			where += " " + name
+		}
+		pass.ReportRangef(call, "revealed Secret stored in %s; keep the Secret and reveal it where it is used instead", where)
+	}
+}
+
+// checkZeroReveals reports calls to Reveal and AppendTo on Secrets that are
+// always zero, which panic. A Secret is known to be zero if it is a composite
+// literal with no fields, or a local variable that is declared without a
+// value and never assigned to or has its address taken.
+func checkZeroReveals(pass *analysis.Pass, body *ast.BlockStmt) {
+	info := pass.TypesInfo
+	zero := make(map[*types.Var]bool)
+
+	// Find the local variables declared without a value, and rule out the
+	// ones that are assigned to or have their address taken.
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Function literals are checked separately, but their
+			// assignments to captured variables still count.
+			return true
+		case *ast.DeclStmt:
+			gd, ok := n.Decl.(*ast.GenDecl)
+			if !ok {
+				return true
+			}
+			for _, spec := range gd.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vs.Values) > 0 {
+					continue
+				}
+				for _, name := range vs.Names {
+					if v, ok := info.Defs[name].(*types.Var); ok && isSecret(v.Type()) {
+						zero[v] = true
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if id, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+					if v, ok := info.ObjectOf(id).(*types.Var); ok {
+						delete(zero, v)
+					}
+				}
+			}
+		case *ast.UnaryExpr:
+			if id, ok := ast.Unparen(n.X).(*ast.Ident); ok {
+				if v, ok := info.Uses[id].(*types.Var); ok {
+					delete(zero, v)
+				}
+			}
+		case *ast.RangeStmt:
+			for _, e := range []ast.Expr{n.Key, n.Value} {
+				if id, ok := e.(*ast.Ident); ok {
+					if v, ok := info.ObjectOf(id).(*types.Var); ok {
+						delete(zero, v)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			// Calling a method with a pointer receiver takes the
+			// address of the variable implicitly.
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+				if s, ok := info.Selections[sel]; ok && s.Kind() == types.MethodVal {
+					if _, ptr := s.Obj().Type().(*types.Signature).Recv().Type().(*types.Pointer); ptr {
+						if id, ok := ast.Unparen(sel.X).(*ast.Ident); ok {
+							if v, ok := info.Uses[id].(*types.Var); ok {
+								delete(zero, v)
+							}
+						}
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		var method string
+		switch {
+		case isSecretMethod(info, call, "Reveal"):
+			method = "Reveal"
+		case isSecretMethod(info, call, "AppendTo"):
+			method = "AppendTo"
+		default:
+			return true
+		}
+		recv := ast.Unparen(call.Fun.(*ast.SelectorExpr).X)
+		switch recv := recv.(type) {
+		case *ast.CompositeLit:
+			if len(recv.Elts) == 0 {
+				pass.ReportRangef(call, "%s called on a zero Secret, which panics", method)
+			}
+		case *ast.Ident:
+			if v, ok := info.Uses[recv].(*types.Var); ok && zero[v] {
+				pass.ReportRangef(call, "%s called on %s, which is always a zero Secret and panics", method, recv.Name)
+			}
+		}
+		return true
+	})
+}
+
+// isSecretMethod reports if call is a call to the named method of
+// camo.Secret.
+func isSecretMethod(info *types.Info, call *ast.CallExpr, name string) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Name() != name {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && isSecret(recv.Type())
+}
+
+// isSecret reports if t is an instance of camo.Secret.
+func isSecret(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == camoPath && obj.Name() == "Secret"
+}
@@ -0,0 +1,15 @@
+// Command camocheck reports misuses of camo Secrets, and revealed Secrets
+// that are likely to be leaked by logging or printing them. It can be run
+// directly, or with go vet:
+//
+//	go vet -vettool=$(which camocheck) ./...
+package main
+
+import (
+	"github.com/rbranson/camo/camocheck"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(camocheck.Analyzer, camocheck.LeakAnalyzer)
+}
@@ -0,0 +1,366 @@
+package camocheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// LeakAnalyzer reports the content of Secrets flowing into fmt, log, and
+// log/slog calls that print, log, or format an error, where the plaintext is
+// likely to be leaked.
+//
+// The content of a Secret is tracked from the methods that reveal it, such as
+// Reveal, AppendTo, RevealInto, WriteTo, Reader, and RevealHex, those of
+// SealedSecret, SecretOf, RateLimitedSecret, and ExpiringSecret, those of
+// Credentials and URL that include the password, RevealArray, and the
+// argument of the functions passed to SealedSecret.WithRevealed and Map. It is tracked through local
+// variables, conversions, slicing, string concatenation, fmt.Sprint and its
+// variants, io.ReadAll, and the String and Bytes methods of buffers, within
+// a single function. It isn't tracked through other function calls, struct
+// fields, or collections, so not every leak is found.
+var LeakAnalyzer = &analysis.Analyzer{
+	Name:     "camoleak",
+	Doc:      "report revealed Secrets passed to logging and printing functions",
+	URL:      "https://pkg.go.dev/github.com/rbranson/camo/camocheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runLeak,
+}
+
+// revealMethods are the methods of camo.Secret, camo.SealedSecret, and
+// camo.SecretOf whose first result holds the content.
+var revealMethods = map[string]bool{
+	"Reveal":              true,
+	"RevealOr":            true,
+	"RevealBase64":        true,
+	"RevealHex":           true,
+	"AppendTo":            true,
+	"AppendBase64To":      true,
+	"AppendHexTo":         true,
+	"AppendQuotedTo":      true,
+	"AppendShellQuotedTo": true,
+	"Reader":              true,
+}
+
+// sourceMethods are the methods whose first result holds the content, by the
+// name of the camo type they are methods of.
+var sourceMethods = map[string]map[string]bool{
+	"Secret":            revealMethods,
+	"SealedSecret":      revealMethods,
+	"SecretOf":          revealMethods,
+	"RateLimitedSecret": {"Reveal": true, "MustReveal": true, "AppendTo": true},
+	"ExpiringSecret":    {"Reveal": true},
+	"Credentials":       {"BasicAuth": true, "Userinfo": true},
+	"URL":               {"Resolve": true},
+}
+
+// sinkMethods are the methods of camo.Secret that reveal the content into
+// their first argument.
+var sinkMethods = map[string]bool{
+	"RevealInto": true,
+	"WriteTo":    true,
+}
+
+func runLeak(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// The functions passed to WithRevealed and Map are given the content as
+	// their argument.
+	revealing := make(map[*ast.FuncLit]bool)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || len(call.Args) == 0 {
+			return
+		}
+		var arg ast.Expr
+		switch {
+		case isCamoMethod(fn, "WithRevealed"):
+			arg = call.Args[0]
+		case isFunc(fn, camoPath) && fn.Name() == "Map" && len(call.Args) == 2:
+			arg = call.Args[1]
+		}
+		if lit, ok := ast.Unparen(arg).(*ast.FuncLit); ok {
+			revealing[lit] = true
+		}
+	})
+
+	nodes := []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	insp.Preorder(nodes, func(n ast.Node) {
+		t := &taint{info: pass.TypesInfo, vars: make(map[*types.Var]bool)}
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+			if params := n.Type.Params.List; revealing[n] && len(params) > 0 && len(params[0].Names) > 0 {
+				t.mark(params[0].Names[0])
+			}
+		}
+		if body != nil {
+			t.propagate(body)
+			t.reportSinks(pass, body)
+		}
+	})
+	return nil, nil
+}
+
+// taint tracks the local variables that hold the content of a Secret within
+// a function.
+type taint struct {
+	info *types.Info
+	vars map[*types.Var]bool
+}
+
+// propagate finds the tainted variables in body. Assignments are visited in
+// source order, twice, so that taint flowing around a loop is found.
+func (t *taint) propagate(body *ast.BlockStmt) {
+	for range 2 {
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				// Analyzed as a function of its own.
+				return false
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, rhs := range n.Rhs {
+						if t.tainted(rhs) {
+							t.mark(n.Lhs[i])
+						}
+					}
+				} else if len(n.Rhs) == 1 && t.tainted(n.Rhs[0]) {
+					// The content is the first result, such as of
+					// RevealArray or SecretOf.Reveal.
+					t.mark(n.Lhs[0])
+				}
+			case *ast.ValueSpec:
+				if len(n.Names) == len(n.Values) {
+					for i, v := range n.Values {
+						if t.tainted(v) {
+							t.mark(n.Names[i])
+						}
+					}
+				} else if len(n.Values) == 1 && t.tainted(n.Values[0]) {
+					t.mark(n.Names[0])
+				}
+			case *ast.CallExpr:
+				if fn, ok := typeutil.Callee(t.info, n).(*types.Func); ok && isCamoMethod(fn, "") && sinkMethods[fn.Name()] && len(n.Args) > 0 {
+					t.mark(n.Args[0])
+				}
+			case *ast.RangeStmt:
+				if t.tainted(n.X) && n.Value != nil {
+					t.mark(n.Value)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// mark taints the variable that e refers to, including through slicing and
+// taking its address, such as for the buffer given to RevealInto or WriteTo.
+func (t *taint) mark(e ast.Expr) {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.SliceExpr:
+			e = x.X
+			continue
+		case *ast.UnaryExpr:
+			if x.Op == token.AND {
+				e = x.X
+				continue
+			}
+		}
+		break
+	}
+	if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+		if v, ok := t.info.ObjectOf(id).(*types.Var); ok {
+			t.vars[v] = true
+		}
+	}
+}
+
+// tainted reports if e evaluates to the content of a Secret.
+func (t *taint) tainted(e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		v, ok := t.info.Uses[e].(*types.Var)
+		return ok && t.vars[v]
+	case *ast.SliceExpr:
+		return t.tainted(e.X)
+	case *ast.IndexExpr:
+		return t.tainted(e.X)
+	case *ast.BinaryExpr:
+		return e.Op == token.ADD && (t.tainted(e.X) || t.tainted(e.Y))
+	case *ast.CallExpr:
+		if fn, ok := typeutil.Callee(t.info, e).(*types.Func); ok {
+			if sourceMethods[camoReceiver(fn)][fn.Name()] {
+				return true
+			}
+			if isFunc(fn, camoPath) && fn.Name() == "RevealArray" {
+				return true
+			}
+			if isFunc(fn, "io") && fn.Name() == "ReadAll" {
+				return t.anyTainted(e.Args)
+			}
+			// The contents of buffers, such as a bytes.Buffer written to
+			// by WriteTo.
+			if sel, ok := e.Fun.(*ast.SelectorExpr); ok && (fn.Name() == "String" || fn.Name() == "Bytes") && fn.Type().(*types.Signature).Recv() != nil {
+				return t.tainted(sel.X)
+			}
+		}
+		if tv, ok := t.info.Types[e.Fun]; ok && tv.IsType() {
+			// A conversion.
+			return len(e.Args) == 1 && t.tainted(e.Args[0])
+		}
+		if fn, ok := typeutil.Callee(t.info, e).(*types.Func); ok && isFunc(fn, "fmt") && strings.HasPrefix(fn.Name(), "Sprint") {
+			return t.anyTainted(e.Args)
+		}
+		if fn, ok := typeutil.Callee(t.info, e).(*types.Builtin); ok && fn.Name() == "append" {
+			return t.anyTainted(e.Args)
+		}
+	}
+	return false
+}
+
+func (t *taint) anyTainted(args []ast.Expr) bool {
+	for _, arg := range args {
+		if t.tainted(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports if e has a tainted subexpression whose value can end up
+// in the result of e, so the length of tainted values isn't counted.
+func (t *taint) contains(e ast.Expr) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case ast.Expr:
+			if call, ok := n.(*ast.CallExpr); ok {
+				if fn, ok := typeutil.Callee(t.info, call).(*types.Builtin); ok && (fn.Name() == "len" || fn.Name() == "cap") {
+					return false
+				}
+			}
+			if t.tainted(n) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// reportSinks reports tainted values passed to sinks in body.
+func (t *taint) reportSinks(pass *analysis.Pass, body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		name, ok := sink(t.info, call)
+		if !ok {
+			return true
+		}
+		for _, arg := range call.Args {
+			if t.contains(arg) {
+				pass.ReportRangef(arg, "revealed Secret passed to %s", name)
+			}
+		}
+		return true
+	})
+}
+
+// sink returns the name of the function called by call if it prints, logs,
+// or formats an error with its arguments.
+func sink(info *types.Info, call *ast.CallExpr) (string, bool) {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return "", false
+	}
+	name := fn.Name()
+	qualified := fn.Pkg().Path() + "." + name
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		named, ok := types.Unalias(derefType(recv.Type())).(*types.Named)
+		if !ok {
+			return "", false
+		}
+		qualified = "(*" + fn.Pkg().Path() + "." + named.Obj().Name() + ")." + name
+	}
+	switch fn.Pkg().Path() {
+	case "fmt":
+		if strings.HasPrefix(name, "Print") || strings.HasPrefix(name, "Fprint") || name == "Errorf" {
+			return qualified, true
+		}
+	case "log":
+		if strings.HasPrefix(name, "Print") || strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic") || name == "Output" {
+			return qualified, true
+		}
+	case "log/slog":
+		switch strings.TrimSuffix(name, "Context") {
+		case "Debug", "Info", "Warn", "Error", "Log", "LogAttrs", "With":
+			return qualified, true
+		}
+	}
+	return "", false
+}
+
+// isCamoMethod reports if fn is a method of camo.Secret, camo.SealedSecret,
+// or camo.SecretOf, with the given name unless it is empty.
+func isCamoMethod(fn *types.Func, name string) bool {
+	if name != "" && fn.Name() != name {
+		return false
+	}
+	switch camoReceiver(fn) {
+	case "Secret", "SealedSecret", "SecretOf":
+		return true
+	}
+	return false
+}
+
+// camoReceiver returns the name of the camo type that fn is a method of,
+// including through a pointer receiver, or "" if it isn't one.
+func camoReceiver(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	named, ok := types.Unalias(derefType(recv.Type())).(*types.Named)
+	if !ok {
+		return ""
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != camoPath {
+		return ""
+	}
+	return obj.Name()
+}
+
+func isFunc(fn *types.Func, pkg string) bool {
+	return fn.Pkg() != nil && fn.Pkg().Path() == pkg && fn.Type().(*types.Signature).Recv() == nil
+}
+
+func derefType(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
@@ -0,0 +1,11 @@
+package camocheck
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestLeakAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), LeakAnalyzer, "leak")
+}
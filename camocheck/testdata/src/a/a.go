@@ -0,0 +1,52 @@
+package a
+
+import "github.com/rbranson/camo"
+
+var apiKey = camo.Obscure("key")
+
+var plainKey = apiKey.Reveal() // want `Secret revealed during package initialization`
+
+var lazyKey = func() string { return apiKey.Reveal() }
+
+var cached string
+
+func init() {
+	cached = apiKey.Reveal() // want `Secret revealed during package initialization` `revealed Secret stored in package-level variable cached`
+}
+
+type Config struct {
+	Password string
+	Secret   camo.Secret[string]
+}
+
+func store(c *Config, s camo.Secret[string]) {
+	c.Password = s.Reveal() // want `revealed Secret stored in struct field Password`
+	cached = s.Reveal()     // want `revealed Secret stored in package-level variable cached`
+	password := s.Reveal()
+	_ = password
+	_ = Config{Password: s.Reveal()} // want `revealed Secret stored in struct field Password`
+	_ = Config{Secret: s}
+}
+
+func zero() {
+	var s camo.Secret[string]
+	s.Reveal() // want `Reveal called on s, which is always a zero Secret and panics`
+
+	camo.Secret[[]byte]{}.AppendTo(nil) // want `AppendTo called on a zero Secret, which panics`
+
+	var t camo.Secret[string]
+	t = camo.Obscure("x")
+	t.Reveal()
+
+	var u camo.Secret[string]
+	set(&u)
+	u.Reveal()
+
+	var w camo.Secret[string]
+	func() { w = camo.Obscure("x") }()
+	w.Reveal()
+}
+
+func set(s *camo.Secret[string]) {
+	*s = camo.Obscure("x")
+}
@@ -0,0 +1,160 @@
+// Package camo is a stub of the camo package for tests.
+package camo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Obscurable interface {
+	string | []byte
+}
+
+type Secret[O Obscurable] struct {
+	p *O
+}
+
+func Obscure[O Obscurable](content O) Secret[O] {
+	return Secret[O]{p: &content}
+}
+
+func (s Secret[O]) Reveal() O {
+	return *s.p
+}
+
+func (s Secret[O]) AppendTo(dst []byte) []byte {
+	return append(dst, *s.p...)
+}
+
+func (s Secret[O]) Valid() bool {
+	return s.p != nil
+}
+
+func (s Secret[O]) RevealOr(def O) O {
+	if s.p == nil {
+		return def
+	}
+	return *s.p
+}
+
+func (s Secret[O]) RevealInto(dst []byte) int {
+	return copy(dst, *s.p)
+}
+
+func (s Secret[O]) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write([]byte(*s.p))
+	return int64(n), err
+}
+
+func (s Secret[O]) Reader() io.ReadCloser {
+	return io.NopCloser(strings.NewReader(string(*s.p)))
+}
+
+func (s Secret[O]) RevealBase64(enc *base64.Encoding) string {
+	return enc.EncodeToString([]byte(*s.p))
+}
+
+func (s Secret[O]) RevealHex() string {
+	return hex.EncodeToString([]byte(*s.p))
+}
+
+func (s Secret[O]) AppendBase64To(dst []byte, enc *base64.Encoding) []byte {
+	return enc.AppendEncode(dst, []byte(*s.p))
+}
+
+func (s Secret[O]) AppendHexTo(dst []byte) []byte {
+	return hex.AppendEncode(dst, []byte(*s.p))
+}
+
+func (s Secret[O]) AppendQuotedTo(dst []byte) []byte {
+	return strconv.AppendQuote(dst, string(*s.p))
+}
+
+func (s Secret[O]) Masked() string {
+	return "…"
+}
+
+func RevealArray[A [16]byte | [32]byte, O Obscurable](s Secret[O]) (A, bool) {
+	var a A
+	return a, false
+}
+
+type SealedSecret[O Obscurable] struct {
+	p *O
+}
+
+func (s SealedSecret[O]) Reveal() O {
+	return *s.p
+}
+
+func (s SealedSecret[O]) AppendTo(dst []byte) []byte {
+	return append(dst, *s.p...)
+}
+
+func (s SealedSecret[O]) WithRevealed(f func(O)) {
+	f(*s.p)
+}
+
+type SecretOf[T any] struct {
+	v *T
+}
+
+func (s SecretOf[T]) Reveal() (T, error) {
+	return *s.v, nil
+}
+
+type RateLimitedSecret[O Obscurable] struct {
+	s Secret[O]
+}
+
+func (r *RateLimitedSecret[O]) Reveal() (O, error) {
+	return r.s.Reveal(), nil
+}
+
+func (r *RateLimitedSecret[O]) MustReveal() O {
+	return r.s.Reveal()
+}
+
+func (r *RateLimitedSecret[O]) AppendTo(dst []byte) ([]byte, error) {
+	return r.s.AppendTo(dst), nil
+}
+
+type ExpiringSecret[O Obscurable] struct {
+	s Secret[O]
+}
+
+func (e *ExpiringSecret[O]) Reveal() (O, error) {
+	return e.s.Reveal(), nil
+}
+
+type Credentials struct {
+	Username string
+	Password Secret[string]
+}
+
+func (c Credentials) BasicAuth() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password.Reveal()))
+}
+
+func (c Credentials) Userinfo() *url.Userinfo {
+	return url.UserPassword(c.Username, c.Password.Reveal())
+}
+
+type URL struct {
+	u        url.URL
+	password Secret[string]
+}
+
+func (u URL) Resolve() *url.URL {
+	ru := u.u
+	ru.User = url.UserPassword("", u.password.Reveal())
+	return &ru
+}
+
+func (u URL) String() string {
+	return u.u.String()
+}
@@ -0,0 +1,103 @@
+package leak
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/rbranson/camo"
+)
+
+func direct(s camo.Secret[string]) {
+	fmt.Println(s.Reveal())                    // want `revealed Secret passed to fmt.Println`
+	log.Printf("password: %s", s.Reveal())     // want `revealed Secret passed to log.Printf`
+	slog.Info("login", "password", s.Reveal()) // want `revealed Secret passed to log/slog.Info`
+}
+
+func flows(s camo.Secret[string], b camo.Secret[[]byte]) error {
+	pw := s.Reveal()
+	dsn := "postgres://app:" + pw + "@db/app"
+	fmt.Fprintf(os.Stderr, "connecting to %s\n", dsn) // want `revealed Secret passed to fmt.Fprintf`
+
+	key := b.AppendTo(nil)
+	str := string(key[:4])
+	logger := slog.Default()
+	logger.Error("bad key", slog.String("key", str)) // want `revealed Secret passed to \(\*log/slog.Logger\).Error`
+
+	msg := fmt.Sprintf("token=%s", pw)
+	l := log.New(os.Stderr, "", 0)
+	l.Println(msg) // want `revealed Secret passed to \(\*log.Logger\).Println`
+
+	fmt.Println(len(pw)) // Only the length is printed.
+	fmt.Println(s)       // Secrets format as redacted.
+
+	return fmt.Errorf("login failed for %s", pw) // want `revealed Secret passed to fmt.Errorf`
+}
+
+func loop(s camo.Secret[string]) {
+	var last string
+	for range 2 {
+		fmt.Println(last) // want `revealed Secret passed to fmt.Println`
+		last = s.Reveal()
+	}
+}
+
+func revealPaths(s camo.Secret[string], sealed camo.SealedSecret[string], of camo.SecretOf[config]) {
+	fmt.Println(s.RevealOr(""))                                   // want `revealed Secret passed to fmt.Println`
+	fmt.Println(s.RevealHex())                                    // want `revealed Secret passed to fmt.Println`
+	fmt.Println(s.RevealBase64(base64.StdEncoding))               // want `revealed Secret passed to fmt.Println`
+	fmt.Printf("%s\n", s.AppendQuotedTo(nil))                     // want `revealed Secret passed to fmt.Printf`
+	fmt.Printf("%s\n", s.AppendHexTo(nil))                        // want `revealed Secret passed to fmt.Printf`
+	fmt.Printf("%s\n", s.AppendBase64To(nil, base64.URLEncoding)) // want `revealed Secret passed to fmt.Printf`
+	fmt.Println(sealed.Reveal())                                  // want `revealed Secret passed to fmt.Println`
+	fmt.Printf("%s\n", sealed.AppendTo(nil))                      // want `revealed Secret passed to fmt.Printf`
+
+	cfg, _ := of.Reveal()
+	log.Print(cfg) // want `revealed Secret passed to log.Print`
+
+	key, ok := camo.RevealArray[[32]byte](s)
+	if ok {
+		log.Print(key) // want `revealed Secret passed to log.Print`
+	}
+
+	buf := make([]byte, 64)
+	n := s.RevealInto(buf)
+	log.Print(buf[:n]) // want `revealed Secret passed to log.Print`
+
+	var b bytes.Buffer
+	s.WriteTo(&b)
+	log.Print(b.String()) // want `revealed Secret passed to log.Print`
+
+	content, _ := io.ReadAll(s.Reader())
+	log.Print(content) // want `revealed Secret passed to log.Print`
+
+	sealed.WithRevealed(func(pw string) {
+		log.Print(pw) // want `revealed Secret passed to log.Print`
+	})
+
+	fmt.Println(s.Masked()) // Masked content is meant to be displayed.
+}
+
+type config struct {
+	Password string
+}
+
+func wrappers(r *camo.RateLimitedSecret[string], e *camo.ExpiringSecret[[]byte], c camo.Credentials, u camo.URL) {
+	pw, _ := r.Reveal()
+	log.Print(pw)             // want `revealed Secret passed to log.Print`
+	log.Print(r.MustReveal()) // want `revealed Secret passed to log.Print`
+	buf, _ := r.AppendTo(nil)
+	log.Print(buf) // want `revealed Secret passed to log.Print`
+
+	key, _ := e.Reveal()
+	log.Print(key) // want `revealed Secret passed to log.Print`
+
+	log.Print(c.BasicAuth()) // want `revealed Secret passed to log.Print`
+	log.Print(c.Userinfo())  // want `revealed Secret passed to log.Print`
+	log.Print(u.Resolve())   // want `revealed Secret passed to log.Print`
+	log.Print(u)             // URLs format without the password.
+}
@@ -0,0 +1,70 @@
+// Package camocobra defines secret-valued flags on pflag FlagSets, such as
+// those of cobra commands. Their values are never printed in help output,
+// and can be given indirectly like those of camo.SecretFlag:
+//
+//	var password camo.Secret[string]
+//	camocobra.SecretVar(cmd.Flags(), &password, "password", "database password, @file, or env:VAR")
+package camocobra
+
+import (
+	"github.com/rbranson/camo"
+	"github.com/spf13/pflag"
+)
+
+// value is a pflag.Value that stores a Secret in p.
+type value struct {
+	p *camo.Secret[string]
+}
+
+// Set sets the Secret to the result of camo.ParseSecretFlag.
+func (v value) Set(s string) error {
+	secret, err := camo.ParseSecretFlag(s)
+	if err != nil {
+		return err
+	}
+	*v.p = secret
+	return nil
+}
+
+// String returns camo.Redacted if the Secret is set, so that a default value
+// is printed as redacted in help output.
+func (v value) String() string {
+	if v.p == nil || !v.p.Valid() {
+		return ""
+	}
+	return camo.Redacted
+}
+
+// Type returns the name of the type of the flag in help output.
+func (v value) Type() string {
+	return "secret"
+}
+
+// SecretVar defines a secret flag with the given name and usage, which
+// stores its value in p. The value of p when SecretVar is called is the
+// default.
+func SecretVar(fs *pflag.FlagSet, p *camo.Secret[string], name, usage string) {
+	fs.Var(value{p: p}, name, usage)
+}
+
+// SecretVarP is like SecretVar, but also takes a one-letter shorthand.
+func SecretVarP(fs *pflag.FlagSet, p *camo.Secret[string], name, shorthand, usage string) {
+	fs.VarP(value{p: p}, name, shorthand, usage)
+}
+
+// Secret defines a secret flag with the given name and usage, and returns a
+// pointer to the Secret that stores its value, which is zero by default.
+func Secret(fs *pflag.FlagSet, name, usage string) *camo.Secret[string] {
+	p := new(camo.Secret[string])
+	SecretVar(fs, p, name, usage)
+	return p
+}
+
+// SecretP is like Secret, but also takes a one-letter shorthand.
+func SecretP(fs *pflag.FlagSet, name, shorthand, usage string) *camo.Secret[string] {
+	p := new(camo.Secret[string])
+	SecretVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+var _ pflag.Value = value{}
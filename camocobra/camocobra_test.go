@@ -0,0 +1,78 @@
+package camocobra
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"github.com/spf13/cobra"
+)
+
+func TestCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CAMO_TEST_COBRA", "from-env")
+
+	var (
+		ran      bool
+		password = camo.Obscure("default-password")
+	)
+	cmd := &cobra.Command{
+		Use: "app",
+		Run: func(cmd *cobra.Command, args []string) { ran = true },
+	}
+	SecretVarP(cmd.Flags(), &password, "password", "p", "database password")
+	token := Secret(cmd.Flags(), "token", "API token")
+	key := SecretP(cmd.Flags(), "key", "k", "signing key")
+
+	cmd.SetArgs([]string{"-p", "hunter2", "--token", "@" + path, "-k", "env:CAMO_TEST_COBRA"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Fatal("command didn't run")
+	}
+	if password.Reveal() != "hunter2" || token.Reveal() != "from-file" || key.Reveal() != "from-env" {
+		t.Errorf("got %q, %q, %q", password.Reveal(), token.Reveal(), key.Reveal())
+	}
+}
+
+func TestUsage(t *testing.T) {
+	password := camo.Obscure("default-password")
+	cmd := &cobra.Command{Use: "app", Run: func(*cobra.Command, []string) {}}
+	SecretVar(cmd.Flags(), &password, "password", "database password")
+	Secret(cmd.Flags(), "token", "API token")
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--help"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	usage := out.String()
+	if strings.Contains(usage, "default-password") {
+		t.Errorf("usage contains the secret:\n%s", usage)
+	}
+	if !strings.Contains(usage, "--password secret") || !strings.Contains(usage, "(default REDACTED)") {
+		t.Errorf("unexpected usage:\n%s", usage)
+	}
+	if strings.Count(usage, "default") != 1 {
+		t.Errorf("expected no default for an unset flag:\n%s", usage)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	cmd := &cobra.Command{Use: "app", Run: func(*cobra.Command, []string) {}}
+	Secret(cmd.Flags(), "token", "API token")
+	cmd.SetArgs([]string{"--token", "env:CAMO_TEST_MISSING"})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil {
+		t.Errorf("expected an error for a missing variable")
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/rbranson/camo/camocobra
+
+go 1.24
+
+require (
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	github.com/spf13/cobra v1.9.1
+	github.com/spf13/pflag v1.0.6
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
@@ -0,0 +1,76 @@
+// Package camocodec adapts camo.Secret to the CBOR (fxamacker/cbor) and
+// MessagePack (vmihailenco/msgpack) codecs, so Secret fields in RPC payload
+// structs can be decoded safely and are always encoded as redacted
+// placeholders.
+package camocodec
+
+import (
+	"bytes"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/rbranson/camo"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Secret wraps a camo.Secret with the marshaling interfaces of the CBOR and
+// MessagePack codecs. All of the methods of the embedded camo.Secret are
+// available on it.
+type Secret[O camo.Obscurable] struct {
+	camo.Secret[O]
+}
+
+// Wrap returns s wrapped for use with the CBOR and MessagePack codecs.
+func Wrap[O camo.Obscurable](s camo.Secret[O]) Secret[O] {
+	return Secret[O]{Secret: s}
+}
+
+// redactedCBOR is camo.Redacted encoded as a CBOR text string.
+var redactedCBOR, _ = cbor.Marshal(camo.Redacted)
+
+// MarshalCBOR implements cbor.Marshaler, encoding camo.Redacted as a text
+// string.
+func (s Secret[O]) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(camo.Redacted)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler, replacing s with a Secret that
+// obscures the decoded content. Like camo.Secret.UnmarshalText, it returns
+// camo.ErrRedacted if the content is camo.Redacted, which is what
+// MarshalCBOR encodes.
+func (s *Secret[O]) UnmarshalCBOR(data []byte) error {
+	// A text string can't be decoded into a []byte, so the placeholder is
+	// checked for before decoding.
+	if bytes.Equal(data, redactedCBOR) {
+		return camo.ErrRedacted
+	}
+	var content O
+	if err := cbor.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	if string(content) == camo.Redacted {
+		return camo.ErrRedacted
+	}
+	s.Secret = camo.Obscure(content)
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder, encoding camo.Redacted as a
+// string.
+func (s Secret[O]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeString(camo.Redacted)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder, replacing s with a Secret
+// that obscures the decoded content. It returns camo.ErrRedacted if the
+// content is camo.Redacted, which is what EncodeMsgpack encodes.
+func (s *Secret[O]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	var content O
+	if err := dec.Decode(&content); err != nil {
+		return err
+	}
+	if string(content) == camo.Redacted {
+		return camo.ErrRedacted
+	}
+	s.Secret = camo.Obscure(content)
+	return nil
+}
@@ -0,0 +1,110 @@
+package camocodec
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/rbranson/camo"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type payload struct {
+	User     string
+	Password Secret[string]
+	Key      Secret[[]byte]
+}
+
+func TestCBOR(t *testing.T) {
+	in := map[string]any{"User": "bob", "Password": "hunter2", "Key": []byte{1, 2, 3}}
+	data, err := cbor.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var p payload
+	if err := cbor.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := p.Password.Reveal(); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if got := p.Key.Reveal(); !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("got = %v; want %v", got, []byte{1, 2, 3})
+	}
+
+	out, err := cbor.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var m map[string]any
+	if err := cbor.Unmarshal(out, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["Password"] != camo.Redacted || m["Key"] != camo.Redacted {
+		t.Errorf("expected redacted output, got %v", m)
+	}
+}
+
+func TestMsgpack(t *testing.T) {
+	in := map[string]any{"User": "bob", "Password": "hunter2", "Key": []byte{1, 2, 3}}
+	data, err := msgpack.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var p payload
+	if err := msgpack.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := p.Password.Reveal(); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if got := p.Key.Reveal(); !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("got = %v; want %v", got, []byte{1, 2, 3})
+	}
+
+	out, err := msgpack.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var m map[string]any
+	if err := msgpack.Unmarshal(out, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["Password"] != camo.Redacted || m["Key"] != camo.Redacted {
+		t.Errorf("expected redacted output, got %v", m)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	s := Wrap(camo.Obscure("abc"))
+	if got := s.Reveal(); got != "abc" {
+		t.Errorf("got = %q; want %q", got, "abc")
+	}
+}
+
+func TestRoundTripRejectsRedacted(t *testing.T) {
+	codecs := map[string]struct {
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte, any) error
+	}{
+		"CBOR":    {cbor.Marshal, cbor.Unmarshal},
+		"Msgpack": {msgpack.Marshal, msgpack.Unmarshal},
+	}
+	for name, c := range codecs {
+		for _, in := range []any{
+			&struct{ Password Secret[string] }{Wrap(camo.Obscure("hunter2"))},
+			&struct{ Key Secret[[]byte] }{Wrap(camo.Obscure([]byte{1, 2, 3}))},
+		} {
+			data, err := c.marshal(in)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+			if err := c.unmarshal(data, in); !errors.Is(err, camo.ErrRedacted) {
+				t.Errorf("%s: got err = %v for %T; want %v", name, err, in, camo.ErrRedacted)
+			}
+		}
+	}
+}
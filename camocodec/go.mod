@@ -0,0 +1,17 @@
+module github.com/rbranson/camo/camocodec
+
+go 1.24
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.0
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
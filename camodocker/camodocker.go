@@ -0,0 +1,47 @@
+// Package camodocker provides a camo.Provider for Docker Swarm, Docker
+// Compose, and Podman secrets, which are mounted as files in /run/secrets.
+package camodocker
+
+import (
+	"context"
+
+	"github.com/rbranson/camo"
+)
+
+// DefaultDir is the directory secrets are mounted in.
+const DefaultDir = "/run/secrets"
+
+// Provider is a camo.Provider that reads secrets from the files they are
+// mounted as, named after the secret.
+//
+// Swarm mounts secrets with mode 0444 by default, so world readable files are
+// accepted. Trailing white space is trimmed, as secrets created from files or
+// with echo usually end with a newline.
+type Provider struct {
+	// Dir is the directory secrets are mounted in. It defaults to
+	// DefaultDir.
+	Dir string
+
+	// NoTrim disables the trimming of trailing white space.
+	NoTrim bool
+}
+
+// Get reads the secret with the given name.
+func (p Provider) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	dir := p.Dir
+	if dir == "" {
+		dir = DefaultDir
+	}
+	opts := []camo.Option{camo.AllowInsecurePermissions()}
+	if !p.NoTrim {
+		opts = append(opts, camo.Trimmed())
+	}
+	return camo.FileProvider{Dir: dir, Options: opts}.Get(ctx, name)
+}
+
+// Get reads the secret with the given name from DefaultDir.
+func Get(name string) (camo.Secret[string], error) {
+	return Provider{}.Get(context.Background(), name)
+}
+
+var _ camo.Provider = Provider{}
@@ -0,0 +1,39 @@
+package camodocker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func TestProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db_password"), []byte("hunter2\n"), 0o444); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	s, err := Provider{Dir: dir}.Get(ctx, "db_password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+
+	s, err = Provider{Dir: dir, NoTrim: true}.Get(ctx, "db_password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2\n" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2\n")
+	}
+
+	if _, err := (Provider{Dir: dir}).Get(ctx, "missing"); !errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want camo.ErrNotFound", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Package camoenvconfig processes envconfig specs whose Secret fields are
+// unset from the environment once they have been read.
+//
+// Secret fields are populated by envconfig.Process without this package, as
+// *camo.Secret implements envconfig.Setter.
+package camoenvconfig
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/rbranson/camo"
+)
+
+// keysTemplate lists the environment variables of each field along with its
+// type, using envconfig's own rules for naming them.
+var keysTemplate = template.Must(template.New("keys").Parse(`{{range .}}{{.Field.Type}}	{{.Key}}	{{.Alt}}
+{{end}}`))
+
+var (
+	stringSecretType = reflect.TypeFor[camo.Secret[string]]().String()
+	bytesSecretType  = reflect.TypeFor[camo.Secret[[]byte]]().String()
+)
+
+// Process populates spec from the environment like envconfig.Process, and
+// then unsets the environment variables that camo.Secret fields may have
+// been read from, so that they aren't inherited by child processes or read
+// elsewhere. Like camo.FromEnv, it can't wipe the copy of the environment
+// kept by the Go runtime.
+//
+// Both the prefixed and unprefixed names of fields with the split_words tag
+// or an envconfig tag are unset, as envconfig falls back from one to the
+// other.
+func Process(prefix string, spec any) error {
+	if err := envconfig.Process(prefix, spec); err != nil {
+		return err
+	}
+	keys, err := secretKeys(prefix, spec)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		os.Unsetenv(key)
+	}
+	return nil
+}
+
+// secretKeys returns the environment variables of the Secret fields of spec.
+func secretKeys(prefix string, spec any) ([]string, error) {
+	var buf bytes.Buffer
+	if err := envconfig.Usaget(prefix, spec, &buf, keysTemplate); err != nil {
+		return nil, err
+	}
+	var keys []string
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		typ, rest, _ := strings.Cut(sc.Text(), "\t")
+		if typ != stringSecretType && typ != bytesSecretType {
+			continue
+		}
+		key, alt, _ := strings.Cut(rest, "\t")
+		keys = append(keys, key)
+		if alt != "" {
+			keys = append(keys, alt)
+		}
+	}
+	return keys, sc.Err()
+}
@@ -0,0 +1,52 @@
+package camoenvconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/rbranson/camo"
+)
+
+type spec struct {
+	Host     string
+	Password camo.Secret[string]
+	APIKey   camo.Secret[[]byte] `split_words:"true"`
+	Token    camo.Secret[string] `envconfig:"SERVICE_TOKEN"`
+}
+
+func TestSetter(t *testing.T) {
+	t.Setenv("APP_PASSWORD", "hunter2")
+	var s spec
+	if err := envconfig.Process("app", &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Password != camo.Obscure("hunter2") {
+		t.Errorf("expected Password to be populated")
+	}
+	if _, ok := os.LookupEnv("APP_PASSWORD"); !ok {
+		t.Errorf("expected envconfig.Process to leave the variable set")
+	}
+}
+
+func TestProcess(t *testing.T) {
+	t.Setenv("APP_HOST", "db")
+	t.Setenv("APP_PASSWORD", "hunter2")
+	t.Setenv("APP_API_KEY", "key")
+	t.Setenv("SERVICE_TOKEN", "token")
+	var s spec
+	if err := Process("app", &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Host != "db" || s.Password.Reveal() != "hunter2" || string(s.APIKey.Reveal()) != "key" || s.Token.Reveal() != "token" {
+		t.Errorf("processed %+v", s)
+	}
+	for _, key := range []string{"APP_PASSWORD", "APP_API_KEY", "SERVICE_TOKEN"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Errorf("expected %s to be unset", key)
+		}
+	}
+	if _, ok := os.LookupEnv("APP_HOST"); !ok {
+		t.Errorf("expected APP_HOST to be left set")
+	}
+}
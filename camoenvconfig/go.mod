@@ -0,0 +1,14 @@
+module github.com/rbranson/camo/camoenvconfig
+
+go 1.24
+
+require (
+	github.com/kelseyhightower/envconfig v1.4.0
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+)
+
+require (
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
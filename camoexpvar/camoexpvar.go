@@ -0,0 +1,30 @@
+// Package camoexpvar publishes the camo Stats with expvar, as a map named
+// "camo" with the keys "reveals", "appends", "zero_panics", and
+// "scrub_hits". Importing the package publishes the map:
+//
+//	import _ "github.com/rbranson/camo/camoexpvar"
+//
+// Note that importing expvar registers its handler at /debug/vars on
+// http.DefaultServeMux.
+package camoexpvar
+
+import (
+	"expvar"
+
+	"github.com/rbranson/camo"
+)
+
+func init() {
+	expvar.Publish("camo", expvar.Func(func() any {
+		return stats(camo.ReadStats())
+	}))
+}
+
+func stats(s camo.Stats) map[string]uint64 {
+	return map[string]uint64{
+		"reveals":     s.Reveals,
+		"appends":     s.Appends,
+		"zero_panics": s.ZeroPanics,
+		"scrub_hits":  s.ScrubHits,
+	}
+}
@@ -0,0 +1,33 @@
+package camoexpvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func TestPublished(t *testing.T) {
+	v := expvar.Get("camo")
+	if v == nil {
+		t.Fatal("expected camo to be published")
+	}
+	var before map[string]uint64
+	if err := json.Unmarshal([]byte(v.String()), &before); err != nil {
+		t.Fatal(err)
+	}
+	camo.Obscure("hunter2").Reveal()
+	var after map[string]uint64
+	if err := json.Unmarshal([]byte(v.String()), &after); err != nil {
+		t.Fatal(err)
+	}
+	if after["reveals"] != before["reveals"]+1 {
+		t.Errorf("reveals = %d; want %d", after["reveals"], before["reveals"]+1)
+	}
+	for _, key := range []string{"appends", "zero_panics", "scrub_hits"} {
+		if _, ok := after[key]; !ok {
+			t.Errorf("missing %q", key)
+		}
+	}
+}
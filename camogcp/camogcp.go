@@ -0,0 +1,128 @@
+// Package camogcp provides a camo.Provider that reads secrets from Google
+// Cloud Secret Manager, using its REST API.
+package camogcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rbranson/camo"
+)
+
+// DefaultEndpoint is the Secret Manager endpoint used when none is set.
+const DefaultEndpoint = "https://secretmanager.googleapis.com"
+
+// Provider is a camo.Provider that reads secrets from Secret Manager.
+//
+// Names are secret IDs in Project, such as "db-password", which access the
+// latest version, or "db-password@3" to pin a version. Full resource names
+// such as "projects/p/secrets/db-password/versions/3" are also accepted.
+type Provider struct {
+	// Client makes the requests, and must add credentials to them, such as
+	// the client returned by golang.org/x/oauth2/google.DefaultClient with
+	// the "https://www.googleapis.com/auth/cloud-platform" scope.
+	Client *http.Client
+
+	// Project is the ID or number of the project that secret IDs belong to.
+	Project string
+
+	// Endpoint is the base URL of the API. It defaults to DefaultEndpoint.
+	Endpoint string
+}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Get accesses the secret version with the given name.
+func (p Provider) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	resource, err := p.resource(name)
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/v1/"+resource+":access", nil)
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return camo.Secret[string]{}, apiError(name, resp)
+	}
+
+	var body struct {
+		Payload struct {
+			Data       string `json:"data"`
+			DataCrc32c string `json:"dataCrc32c"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return camo.Secret[string]{}, fmt.Errorf("camogcp: decoding response for %q: %w", name, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(body.Payload.Data)
+	if err != nil {
+		clear(data)
+		return camo.Secret[string]{}, fmt.Errorf("camogcp: decoding payload of %q: %w", name, err)
+	}
+	if body.Payload.DataCrc32c != "" {
+		want, err := strconv.ParseUint(body.Payload.DataCrc32c, 10, 32)
+		if err != nil || crc32.Checksum(data, castagnoli) != uint32(want) {
+			clear(data)
+			return camo.Secret[string]{}, fmt.Errorf("camogcp: payload of %q failed its checksum", name)
+		}
+	}
+	return camo.ObscureOwned(data).AsString(), nil
+}
+
+// resource returns the resource name of the secret version for name.
+func (p Provider) resource(name string) (string, error) {
+	if strings.HasPrefix(name, "projects/") {
+		return name, nil
+	}
+	if p.Project == "" {
+		return "", fmt.Errorf("camogcp: no project for secret %q", name)
+	}
+	id, version, ok := strings.Cut(name, "@")
+	if !ok {
+		version = "latest"
+	}
+	if id == "" || version == "" {
+		return "", fmt.Errorf("camogcp: invalid secret name %q", name)
+	}
+	return "projects/" + url.PathEscape(p.Project) + "/secrets/" + url.PathEscape(id) + "/versions/" + url.PathEscape(version), nil
+}
+
+func apiError(name string, resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&body); err != nil || body.Error.Message == "" {
+		return fmt.Errorf("camogcp: accessing %q: %s", name, resp.Status)
+	}
+	return fmt.Errorf("camogcp: accessing %q: %s: %s", name, body.Error.Status, body.Error.Message)
+}
+
+var _ camo.Provider = Provider{}
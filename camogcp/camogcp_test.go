@@ -0,0 +1,86 @@
+package camogcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func newServer(t *testing.T, versions map[string]string) Provider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := versions[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404,"message":"not found","status":"NOT_FOUND"}}`))
+			return
+		}
+		if value == "denied" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"permission denied","status":"PERMISSION_DENIED"}}`))
+			return
+		}
+		crc := strconv.FormatUint(uint64(crc32.Checksum([]byte(value), castagnoli)), 10)
+		if value == "corrupt" {
+			crc = "1"
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"name": r.URL.Path,
+			"payload": map[string]any{
+				"data":       base64.StdEncoding.EncodeToString([]byte(value)),
+				"dataCrc32c": crc,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return Provider{Client: srv.Client(), Project: "proj", Endpoint: srv.URL}
+}
+
+func TestGet(t *testing.T) {
+	p := newServer(t, map[string]string{
+		"/v1/projects/proj/secrets/db/versions/latest:access":      "new",
+		"/v1/projects/proj/secrets/db/versions/1:access":           "old",
+		"/v1/projects/other/secrets/db/versions/2:access":          "other",
+		"/v1/projects/proj/secrets/denied/versions/latest:access":  "denied",
+		"/v1/projects/proj/secrets/corrupt/versions/latest:access": "corrupt",
+	})
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"db":                                   "new",
+		"db@1":                                 "old",
+		"projects/other/secrets/db/versions/2": "other",
+	}
+	for name, want := range tests {
+		s, err := p.Get(ctx, name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != want {
+			t.Errorf("Get(%q) = %q; want %q", name, got, want)
+		}
+	}
+
+	if _, err := p.Get(ctx, "missing"); !errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want camo.ErrNotFound", err)
+	}
+	for _, name := range []string{"denied", "corrupt", "@1"} {
+		if _, err := p.Get(ctx, name); err == nil || errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want an error", name, err)
+		}
+	}
+}
+
+func TestGetNoProject(t *testing.T) {
+	if _, err := (Provider{}).Get(context.Background(), "db"); err == nil {
+		t.Errorf("expected an error without a project")
+	}
+}
@@ -0,0 +1,51 @@
+package camogrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func TestGetRequestMetadata(t *testing.T) {
+	c := NewPerRPCCredentials("Bearer", camo.Obscure("tok"))
+	md, err := c.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := md["authorization"], "Bearer tok"; got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+	if !c.RequireTransportSecurity() {
+		t.Errorf("expected transport security to be required by default")
+	}
+}
+
+func TestGetRequestMetadataRotation(t *testing.T) {
+	current := camo.Obscure("one")
+	c := &PerRPCCredentials{
+		Key:           "x-api-key",
+		Secret:        func() camo.Secret[string] { return current },
+		AllowInsecure: true,
+	}
+	for _, want := range []string{"one", "two"} {
+		current = camo.Obscure(want)
+		md, err := c.GetRequestMetadata(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := md["x-api-key"]; got != want {
+			t.Errorf("got = %q; want %q", got, want)
+		}
+	}
+	if c.RequireTransportSecurity() {
+		t.Errorf("expected transport security to not be required")
+	}
+}
+
+func TestGetRequestMetadataZero(t *testing.T) {
+	c := NewPerRPCCredentials("Bearer", camo.Secret[string]{})
+	if _, err := c.GetRequestMetadata(context.Background()); err == nil {
+		t.Errorf("expected error for zero secret")
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/rbranson/camo/camogrpc
+
+go 1.24
+
+require (
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	google.golang.org/grpc v1.64.0
+)
+
+require (
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+)
@@ -0,0 +1,32 @@
+package camohttp
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/rbranson/camo"
+)
+
+// SetBasicAuth sets the request's Authorization header to use HTTP Basic
+// Authentication with the provided username and password, like
+// http.Request.SetBasicAuth. The credentials are encoded directly into the
+// header value, and the intermediate buffers are wiped afterwards.
+//
+// It panics if password is a zero Secret.
+func SetBasicAuth(req *http.Request, username string, password camo.Secret[string]) {
+	// Reallocations would leave unwiped copies behind, so start with room for
+	// the whole password.
+	creds := make([]byte, 0, len(username)+1+password.Len())
+	creds = append(creds, username...)
+	creds = append(creds, ':')
+	creds = password.AppendTo(creds)
+	defer clear(creds)
+
+	const prefix = "Basic "
+	value := make([]byte, len(prefix)+base64.StdEncoding.EncodedLen(len(creds)))
+	copy(value, prefix)
+	base64.StdEncoding.Encode(value[len(prefix):], creds)
+	defer clear(value)
+
+	req.Header.Set("Authorization", string(value))
+}
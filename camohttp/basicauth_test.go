@@ -0,0 +1,31 @@
+package camohttp
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func TestSetBasicAuth(t *testing.T) {
+	cases := []struct {
+		username string
+		password string
+	}{
+		{"bob", "hunter2"},
+		{"", ""},
+		{"alice", strings.Repeat("x", 200)},
+	}
+	for _, tc := range cases {
+		want := httptest.NewRequest("GET", "http://example.com", nil)
+		want.SetBasicAuth(tc.username, tc.password)
+
+		got := httptest.NewRequest("GET", "http://example.com", nil)
+		SetBasicAuth(got, tc.username, camo.Obscure(tc.password))
+
+		if g, w := got.Header.Get("Authorization"), want.Header.Get("Authorization"); g != w {
+			t.Errorf("got = %q; want %q", g, w)
+		}
+	}
+}
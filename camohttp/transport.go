@@ -0,0 +1,81 @@
+// Package camohttp provides net/http helpers that place camo Secrets into
+// requests, so that application code never handles their content.
+package camohttp
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rbranson/camo"
+)
+
+// Transport is an http.RoundTripper that sets a header on every request from
+// a Secret, such as "Authorization: Bearer <secret>".
+type Transport struct {
+	// Base is the RoundTripper used to make requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Header is the name of the header to set. If empty, "Authorization" is
+	// used.
+	Header string
+
+	// Scheme, if not empty, is placed before the secret in the header value,
+	// separated by a space.
+	Scheme string
+
+	// Secret returns the secret to place in the header. It is called for
+	// every request, so that a rotated secret is picked up by the next
+	// request.
+	Secret func() camo.Secret[string]
+}
+
+// NewTransport returns a Transport that sets "Authorization: <scheme>
+// <secret>" on every request made through base.
+func NewTransport(base http.RoundTripper, scheme string, secret camo.Secret[string]) *Transport {
+	return &Transport{
+		Base:   base,
+		Scheme: scheme,
+		Secret: func() camo.Secret[string] { return secret },
+	}
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned before the
+// header is set, so req is not modified.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	secret := t.Secret()
+	if !secret.Valid() {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, errors.New("camohttp: transport secret is zero")
+	}
+
+	// Reallocations would leave unwiped copies behind, so start with room for
+	// the whole value.
+	value := make([]byte, 0, len(t.Scheme)+1+secret.Len())
+	if t.Scheme != "" {
+		value = append(value, t.Scheme...)
+		value = append(value, ' ')
+	}
+	value = secret.AppendTo(value)
+
+	req2 := req.Clone(req.Context())
+	req2.Header.Set(t.header(), string(value))
+	clear(value)
+	return t.base().RoundTrip(req2)
+}
+
+func (t *Transport) header() string {
+	if t.Header == "" {
+		return "Authorization"
+	}
+	return t.Header
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
@@ -0,0 +1,71 @@
+package camohttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransport(t *testing.T) {
+	var got string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("Authorization")
+		return httptest.NewRecorder().Result(), nil
+	})
+
+	req := httptest.NewRequest("GET", "http://example.com", nil)
+	tr := NewTransport(base, "Bearer", camo.Obscure("tok"))
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Bearer tok"; got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("original request was modified")
+	}
+}
+
+func TestTransportCustomHeaderAndRotation(t *testing.T) {
+	var got string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("X-Api-Key")
+		return httptest.NewRecorder().Result(), nil
+	})
+
+	current := camo.Obscure("one")
+	tr := &Transport{
+		Base:   base,
+		Header: "X-Api-Key",
+		Secret: func() camo.Secret[string] { return current },
+	}
+
+	for _, want := range []string{"one", "two"} {
+		current = camo.Obscure(want)
+		if _, err := tr.RoundTrip(httptest.NewRequest("GET", "http://example.com", nil)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("got = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestTransportZeroSecret(t *testing.T) {
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("base should not be called")
+		return nil, nil
+	})
+	tr := NewTransport(base, "Bearer", camo.Secret[string]{})
+	if _, err := tr.RoundTrip(httptest.NewRequest("GET", "http://example.com", nil)); err == nil {
+		t.Errorf("expected error for zero secret")
+	}
+}
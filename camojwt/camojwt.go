@@ -0,0 +1,69 @@
+// Package camojwt signs and verifies JSON Web Tokens with github.com/golang-jwt/jwt
+// using key material held in camo Secrets.
+//
+// For HMAC signing methods the Secret holds the raw key. For RSA, RSA-PSS,
+// ECDSA and EdDSA signing methods it holds the PEM encoded private key, from
+// which the public key used for verification is derived.
+package camojwt
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rbranson/camo"
+)
+
+// Sign creates a token with the given claims and signs it using alg and
+// key.
+func Sign(claims jwt.Claims, alg jwt.SigningMethod, key camo.Secret[[]byte]) (string, error) {
+	k, err := signingKey(alg, key)
+	if err != nil {
+		return "", err
+	}
+	if b, ok := k.([]byte); ok {
+		defer clear(b)
+	}
+	return jwt.NewWithClaims(alg, claims).SignedString(k)
+}
+
+// Keyfunc returns a jwt.Keyfunc that provides the key for verifying tokens
+// signed using alg with key. Tokens signed using any other method are
+// rejected.
+func Keyfunc(alg jwt.SigningMethod, key camo.Secret[[]byte]) jwt.Keyfunc {
+	return func(t *jwt.Token) (any, error) {
+		if t.Method.Alg() != alg.Alg() {
+			return nil, fmt.Errorf("camojwt: unexpected signing method %q", t.Method.Alg())
+		}
+		k, err := signingKey(alg, key)
+		if err != nil {
+			return nil, err
+		}
+		if signer, ok := k.(crypto.Signer); ok {
+			return signer.Public(), nil
+		}
+		return k, nil
+	}
+}
+
+// signingKey returns the key that alg signs with. For HMAC this is a revealed
+// copy of key that the caller should wipe once done with it.
+func signingKey(alg jwt.SigningMethod, key camo.Secret[[]byte]) (any, error) {
+	switch alg.(type) {
+	case *jwt.SigningMethodHMAC:
+		return key.Reveal(), nil
+	}
+
+	pemBytes := key.Reveal()
+	defer clear(pemBytes)
+	switch alg.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodECDSA:
+		return jwt.ParseECPrivateKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodEd25519:
+		return jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+	default:
+		return nil, fmt.Errorf("camojwt: unsupported signing method %q", alg.Alg())
+	}
+}
@@ -0,0 +1,86 @@
+package camojwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rbranson/camo"
+)
+
+func pemKey(t *testing.T, key any) camo.Secret[[]byte] {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return camo.Obscure(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		alg jwt.SigningMethod
+		key camo.Secret[[]byte]
+	}{
+		{jwt.SigningMethodHS256, camo.Obscure([]byte("hmac-key"))},
+		{jwt.SigningMethodRS256, pemKey(t, rsaKey)},
+		{jwt.SigningMethodPS256, pemKey(t, rsaKey)},
+		{jwt.SigningMethodES256, pemKey(t, ecKey)},
+		{jwt.SigningMethodEdDSA, pemKey(t, edKey)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.alg.Alg(), func(t *testing.T) {
+			signed, err := Sign(jwt.MapClaims{"sub": "bob"}, tc.alg, tc.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tok, err := jwt.Parse(signed, Keyfunc(tc.alg, tc.key))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sub, _ := tok.Claims.GetSubject(); sub != "bob" {
+				t.Errorf("sub = %q; want %q", sub, "bob")
+			}
+		})
+	}
+}
+
+func TestKeyfuncRejectsOtherMethods(t *testing.T) {
+	key := camo.Obscure([]byte("hmac-key"))
+	signed, err := Sign(jwt.MapClaims{"sub": "bob"}, jwt.SigningMethodHS384, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := jwt.Parse(signed, Keyfunc(jwt.SigningMethodHS256, key)); err == nil {
+		t.Errorf("expected token signed with another method to be rejected")
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	signed, err := Sign(jwt.MapClaims{"sub": "bob"}, jwt.SigningMethodHS256, camo.Obscure([]byte("a")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := jwt.Parse(signed, Keyfunc(jwt.SigningMethodHS256, camo.Obscure([]byte("b")))); err == nil {
+		t.Errorf("expected verification with the wrong key to fail")
+	}
+}
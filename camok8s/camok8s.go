@@ -0,0 +1,300 @@
+// Package camok8s provides camo.Providers for Kubernetes secrets, either read
+// from secret volumes mounted into a pod, or from the Kubernetes API.
+package camok8s
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rbranson/camo"
+)
+
+// DefaultMountDir is the directory Mount reads from when Dir is empty.
+const DefaultMountDir = "/var/run/secrets"
+
+// Mount is a camo.Provider that reads secrets from secret volumes mounted
+// under a directory, with each secret mounted in a directory named after it.
+// Names take the form "secret/key", which is read from Dir/secret/key.
+//
+// Kubernetes mounts secret files with mode 0644 by default, so group and world
+// readable files are accepted.
+type Mount struct {
+	Dir string
+}
+
+// Get reads the key of a mounted secret.
+func (m Mount) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	if strings.Count(name, "/") != 1 {
+		return camo.Secret[string]{}, fmt.Errorf("camok8s: invalid secret name %q, want \"secret/key\"", name)
+	}
+	dir := m.Dir
+	if dir == "" {
+		dir = DefaultMountDir
+	}
+	p := camo.FileProvider{Dir: dir, Options: []camo.Option{camo.AllowInsecurePermissions()}}
+	return p.Get(ctx, name)
+}
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// API is a camo.Provider that reads secrets from the Kubernetes API. Names
+// take the form "namespace/secret/key", or "secret/key" for secrets in
+// Namespace.
+type API struct {
+	// Host is the base URL of the API server.
+	Host string
+
+	// Client makes the requests. It must trust the API server's certificate.
+	Client *http.Client
+
+	// Namespace is the namespace of secrets whose name doesn't include one.
+	Namespace string
+
+	// Token returns the bearer token to authenticate with. It is called for
+	// every request, so that rotated tokens are picked up.
+	Token func() (camo.Secret[[]byte], error)
+}
+
+// InCluster returns an API configured from the service account of the pod it
+// runs in, reading secrets from the pod's namespace.
+func InCluster() (*API, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("camok8s: not running in a cluster")
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("camok8s: no certificates in service account CA bundle")
+	}
+	namespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &API{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		Client:    &http.Client{Transport: transport},
+		Namespace: strings.TrimSpace(string(namespace)),
+		Token: func() (camo.Secret[[]byte], error) {
+			return camo.FromFile(filepath.Join(serviceAccountDir, "token"), camo.AllowInsecurePermissions(), camo.Trimmed())
+		},
+	}, nil
+}
+
+// ref is a reference to a key of a secret.
+type ref struct {
+	namespace, secret, key string
+}
+
+func (a *API) parse(name string) (ref, error) {
+	parts := strings.Split(name, "/")
+	var r ref
+	switch len(parts) {
+	case 2:
+		r = ref{a.Namespace, parts[0], parts[1]}
+	case 3:
+		r = ref{parts[0], parts[1], parts[2]}
+	default:
+		return ref{}, fmt.Errorf("camok8s: invalid secret name %q, want \"namespace/secret/key\"", name)
+	}
+	if r.namespace == "" || r.secret == "" || r.key == "" {
+		return ref{}, fmt.Errorf("camok8s: invalid secret name %q, want \"namespace/secret/key\"", name)
+	}
+	return r, nil
+}
+
+// secretObject is the subset of a Secret object that is used.
+type secretObject struct {
+	Data map[string]string `json:"data"`
+}
+
+// value returns the decoded value of key, or false if there is none.
+func (o *secretObject) value(key string) (camo.Secret[string], bool, error) {
+	encoded, ok := o.Data[key]
+	if !ok {
+		return camo.Secret[string]{}, false, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		clear(data)
+		return camo.Secret[string]{}, false, fmt.Errorf("camok8s: decoding key %q: %w", key, err)
+	}
+	return camo.ObscureOwned(data).AsString(), true, nil
+}
+
+// Get reads the key of a secret from the API.
+func (a *API) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	r, err := a.parse(name)
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	resp, err := a.do(ctx, "/api/v1/namespaces/"+url.PathEscape(r.namespace)+"/secrets/"+url.PathEscape(r.secret))
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return camo.Secret[string]{}, apiError(resp)
+	}
+	var obj secretObject
+	if err := json.NewDecoder(resp.Body).Decode(&obj); err != nil {
+		return camo.Secret[string]{}, fmt.Errorf("camok8s: decoding secret %q: %w", name, err)
+	}
+	s, ok, err := obj.value(r.key)
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	return s, nil
+}
+
+// Watch watches the key of a secret with the watch API, and calls onChange
+// with its value when it is first seen and every time it changes. Watches
+// that end or fail are restarted, unless the API rejects the request, such
+// as when the token isn't authorized, which is reported as an error. It
+// blocks until ctx is canceled, and then returns ctx.Err().
+func (a *API) Watch(ctx context.Context, name string, onChange func(camo.Secret[string])) error {
+	r, err := a.parse(name)
+	if err != nil {
+		return err
+	}
+	q := url.Values{"watch": {"1"}, "fieldSelector": {"metadata.name=" + r.secret}}
+	path := "/api/v1/namespaces/" + url.PathEscape(r.namespace) + "/secrets?" + q.Encode()
+	var last camo.Secret[string]
+	for {
+		// Other errors are dealt with by restarting the watch, like a watch
+		// that timed out.
+		if err := a.watch(ctx, path, r.key, &last, onChange); rejected(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (a *API) watch(ctx context.Context, path, key string, last *camo.Secret[string], onChange func(camo.Secret[string])) error {
+	resp, err := a.do(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError(resp)
+	}
+	dec := json.NewDecoder(bufio.NewReader(resp.Body))
+	for {
+		var event struct {
+			Type   string       `json:"type"`
+			Object secretObject `json:"object"`
+		}
+		if err := dec.Decode(&event); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if event.Type != "ADDED" && event.Type != "MODIFIED" {
+			continue
+		}
+		s, ok, err := event.Object.value(key)
+		if err != nil {
+			return err
+		}
+		if ok && s != *last {
+			*last = s
+			onChange(s)
+		}
+	}
+}
+
+func (a *API) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(a.Host, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if a.Token != nil {
+		token, err := a.Token()
+		if err != nil {
+			return nil, fmt.Errorf("camok8s: reading token: %w", err)
+		}
+		// Reallocations would leave unwiped copies behind, so start with room
+		// for the whole value.
+		value := append(make([]byte, 0, len("Bearer ")+token.Len()), "Bearer "...)
+		value = token.AppendTo(value)
+		req.Header.Set("Authorization", string(value))
+		clear(value)
+	}
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// statusError is an error response from the API.
+type statusError struct {
+	status  string
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	if e.message == "" {
+		return "camok8s: " + e.status
+	}
+	return "camok8s: " + e.status + ": " + e.message
+}
+
+func apiError(resp *http.Response) error {
+	var status struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&status)
+	return &statusError{status: resp.Status, code: resp.StatusCode, message: status.Message}
+}
+
+// rejected reports if err is a client error response from the API, which
+// retrying the same request won't fix, unlike a timeout or a rate limit.
+func rejected(err error) bool {
+	var se *statusError
+	if !errors.As(err, &se) {
+		return false
+	}
+	switch se.code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return se.code >= 400 && se.code < 500
+}
+
+var (
+	_ camo.Provider = Mount{}
+	_ camo.Provider = (*API)(nil)
+)
@@ -0,0 +1,153 @@
+package camok8s
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rbranson/camo"
+)
+
+func TestMount(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "db"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("hunter2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := Mount{Dir: dir}
+	s, err := m.Get(context.Background(), "db/password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if _, err := m.Get(context.Background(), "db/missing"); !errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want camo.ErrNotFound", err)
+	}
+	if _, err := m.Get(context.Background(), "password"); err == nil {
+		t.Errorf("expected an error for a name without a key")
+	}
+}
+
+func secretJSON(data map[string]string) map[string]any {
+	encoded := map[string]string{}
+	for k, v := range data {
+		encoded[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	return map[string]any{"kind": "Secret", "data": encoded}
+}
+
+func newAPI(t *testing.T, handler http.HandlerFunc) *API {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"kind":"Status","message":"Unauthorized"}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return &API{
+		Host:      srv.URL,
+		Client:    srv.Client(),
+		Namespace: "default",
+		Token:     func() (camo.Secret[[]byte], error) { return camo.Obscure([]byte("token")), nil },
+	}
+}
+
+func TestAPIGet(t *testing.T) {
+	a := newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/secrets/db":
+			json.NewEncoder(w).Encode(secretJSON(map[string]string{"password": "hunter2"}))
+		case "/api/v1/namespaces/prod/secrets/db":
+			json.NewEncoder(w).Encode(secretJSON(map[string]string{"password": "prod"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","message":"not found"}`))
+		}
+	})
+	ctx := context.Background()
+	tests := map[string]string{
+		"db/password":      "hunter2",
+		"prod/db/password": "prod",
+	}
+	for name, want := range tests {
+		s, err := a.Get(ctx, name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != want {
+			t.Errorf("Get(%q) = %q; want %q", name, got, want)
+		}
+	}
+	for _, name := range []string{"missing/password", "db/missing"} {
+		if _, err := a.Get(ctx, name); !errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want camo.ErrNotFound", name, err)
+		}
+	}
+	if _, err := a.Get(ctx, "db"); err == nil {
+		t.Errorf("expected an error for an invalid name")
+	}
+
+	a.Token = func() (camo.Secret[[]byte], error) { return camo.Obscure([]byte("wrong")), nil }
+	if _, err := a.Get(ctx, "db/password"); err == nil || errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want an authorization error", err)
+	}
+}
+
+func TestAPIWatch(t *testing.T) {
+	a := newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("watch") != "1" || r.URL.Query().Get("fieldSelector") != "metadata.name=db" {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		enc := json.NewEncoder(w)
+		for _, event := range []struct {
+			typ, value string
+		}{{"ADDED", "v1"}, {"MODIFIED", "v1"}, {"MODIFIED", "v2"}} {
+			enc.Encode(map[string]any{"type": event.typ, "object": secretJSON(map[string]string{"password": event.value})})
+			w.(http.Flusher).Flush()
+		}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var seen []string
+	err := a.Watch(ctx, "db/password", func(s camo.Secret[string]) {
+		seen = append(seen, s.Reveal())
+		if len(seen) == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v; want context.Canceled", err)
+	}
+	if got := fmt.Sprint(seen); got != "[v1 v2]" {
+		t.Errorf("seen = %s; want [v1 v2]", got)
+	}
+}
+
+func TestAPIWatchUnauthorized(t *testing.T) {
+	a := newAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected authorized request")
+	})
+	a.Token = func() (camo.Secret[[]byte], error) { return camo.Obscure([]byte("wrong")), nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := a.Watch(ctx, "db/password", func(camo.Secret[string]) {})
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v; want an authorization error", err)
+	}
+}
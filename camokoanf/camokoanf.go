@@ -0,0 +1,64 @@
+// Package camokoanf unmarshals configs loaded by koanf into structs with
+// camo Secret fields, using the decode hook of camomapstructure.
+//
+// koanf's default unmarshaling decodes strings into Secrets through their
+// UnmarshalText method, but not byte slices, such as those loaded by the
+// rawbytes provider, and a custom UnmarshalConf.DecoderConfig replaces the
+// default hooks. The functions in this package handle both cases:
+//
+//	var cfg struct {
+//		Host     string              `koanf:"host"`
+//		Password camo.Secret[string] `koanf:"password"`
+//	}
+//	err := camokoanf.Unmarshal(k, "db", &cfg)
+package camokoanf
+
+import (
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/v2"
+	"github.com/rbranson/camo/camomapstructure"
+)
+
+// Unmarshal is like k.Unmarshal, but decodes strings and byte slices into
+// Secret fields.
+func Unmarshal(k *koanf.Koanf, path string, out any) error {
+	return UnmarshalWithConf(k, path, out, koanf.UnmarshalConf{})
+}
+
+// UnmarshalWithConf is like k.UnmarshalWithConf, but decodes strings and
+// byte slices into Secret fields. If conf has a DecoderConfig, the decode
+// hook is run before its own, and it is otherwise left as it is. If it
+// doesn't, one is created by DecoderConfig.
+func UnmarshalWithConf(k *koanf.Koanf, path string, out any, conf koanf.UnmarshalConf) error {
+	if conf.DecoderConfig == nil {
+		conf.DecoderConfig = DecoderConfig(out)
+	} else {
+		dc := *conf.DecoderConfig
+		dc.DecodeHook = withHook(dc.DecodeHook)
+		conf.DecoderConfig = &dc
+	}
+	return k.UnmarshalWithConf(path, out, conf)
+}
+
+// DecoderConfig returns a mapstructure config for decoding into out that
+// matches the one used by koanf by default, with the decode hook of
+// camomapstructure added. It can be modified and passed in an
+// UnmarshalConf.
+func DecoderConfig(out any) *mapstructure.DecoderConfig {
+	return &mapstructure.DecoderConfig{
+		DecodeHook: withHook(mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+		)),
+		Result:           out,
+		WeaklyTypedInput: true,
+	}
+}
+
+// withHook returns hook with the decode hook of camomapstructure run first.
+func withHook(hook mapstructure.DecodeHookFunc) mapstructure.DecodeHookFunc {
+	if hook == nil {
+		return camomapstructure.DecodeHook()
+	}
+	return mapstructure.ComposeDecodeHookFunc(camomapstructure.DecodeHook(), hook)
+}
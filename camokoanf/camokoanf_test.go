@@ -0,0 +1,75 @@
+package camokoanf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+	"github.com/rbranson/camo"
+)
+
+type config struct {
+	Host     string              `koanf:"host"`
+	Timeout  time.Duration       `koanf:"timeout"`
+	Password camo.Secret[string] `koanf:"password"`
+	Key      camo.Secret[[]byte] `koanf:"key"`
+}
+
+func load(t *testing.T) *koanf.Koanf {
+	t.Helper()
+	k := koanf.New(".")
+	err := k.Load(confmap.Provider(map[string]any{
+		"db.host":     "db",
+		"db.timeout":  "5s",
+		"db.password": "hunter2",
+		"db.key":      []byte("secret-key"),
+	}, "."), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func check(t *testing.T, cfg config) {
+	t.Helper()
+	if cfg.Host != "db" || cfg.Timeout != 5*time.Second {
+		t.Errorf("decoded %+v", cfg)
+	}
+	if cfg.Password.Reveal() != "hunter2" || string(cfg.Key.Reveal()) != "secret-key" {
+		t.Errorf("decoded %+v", cfg)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var cfg config
+	if err := Unmarshal(load(t), "db", &cfg); err != nil {
+		t.Fatal(err)
+	}
+	check(t, cfg)
+}
+
+func TestUnmarshalWithConf(t *testing.T) {
+	var cfg config
+	dc := &mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		Result:     &cfg,
+	}
+	err := UnmarshalWithConf(load(t), "db", &cfg, koanf.UnmarshalConf{DecoderConfig: dc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, cfg)
+
+	var flat struct {
+		Password camo.Secret[string] `koanf:"db.password"`
+	}
+	err = UnmarshalWithConf(load(t), "", &flat, koanf.UnmarshalConf{FlatPaths: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flat.Password.Reveal() != "hunter2" {
+		t.Errorf("decoded %+v", flat)
+	}
+}
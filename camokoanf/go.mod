@@ -0,0 +1,20 @@
+module github.com/rbranson/camo/camokoanf
+
+go 1.24
+
+require (
+	github.com/go-viper/mapstructure/v2 v2.2.1
+	github.com/knadh/koanf/providers/confmap v1.0.0
+	github.com/knadh/koanf/v2 v2.1.2
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	github.com/rbranson/camo/camomapstructure v0.0.0-20261016170954-35c34b5a793c
+)
+
+require (
+	github.com/knadh/koanf/maps v0.1.2 // indirect
+	github.com/mitchellh/copystructure v1.2.0 // indirect
+	github.com/mitchellh/reflectwalk v1.0.2 // indirect
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
@@ -0,0 +1,48 @@
+// Package camologrus integrates camo with the logrus logging library.
+package camologrus
+
+import (
+	"fmt"
+
+	"github.com/rbranson/camo"
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus.Hook that renders camo.Secret fields as camo.Redacted and
+// scrubs the content of registered secrets (see camo.Register) from the
+// message and fields of every entry.
+//
+// Fields other than strings and Secrets are formatted with fmt to check them
+// for registered content, and are replaced with their scrubbed string form if
+// any is found.
+type Hook struct{}
+
+// Levels implements logrus.Hook. The hook fires for all levels.
+func (Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (Hook) Fire(entry *logrus.Entry) error {
+	entry.Message = camo.Scrub(entry.Message)
+	for k, v := range entry.Data {
+		entry.Data[k] = scrubValue(v)
+	}
+	return nil
+}
+
+func scrubValue(v any) any {
+	switch v := v.(type) {
+	case camo.Secret[string], camo.Secret[[]byte]:
+		return camo.Redacted
+	case string:
+		return camo.Scrub(v)
+	case nil:
+		return nil
+	}
+	str := fmt.Sprintf("%+v", v)
+	if scrubbed := camo.Scrub(str); scrubbed != str {
+		return scrubbed
+	}
+	return v
+}
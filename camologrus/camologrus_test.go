@@ -0,0 +1,34 @@
+package camologrus
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"github.com/sirupsen/logrus"
+)
+
+func TestHook(t *testing.T) {
+	s := camo.Obscure("hunter2")
+	camo.Register(s)
+	t.Cleanup(func() { camo.Unregister(s) })
+
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.Out = &buf
+	logger.Formatter = &logrus.TextFormatter{DisableTimestamp: true, DisableQuote: true}
+	logger.AddHook(Hook{})
+
+	logger.WithFields(logrus.Fields{
+		"secret":   s,
+		"password": s.Reveal(),
+		"count":    2,
+	}).WithError(errors.New("bad password hunter2")).Info("logging in with hunter2")
+
+	got := buf.String()
+	want := "level=info msg=logging in with *** count=2 error=bad password *** password=*** secret=REDACTED\n"
+	if got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
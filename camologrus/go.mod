@@ -0,0 +1,14 @@
+module github.com/rbranson/camo/camologrus
+
+go 1.24
+
+require (
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	github.com/sirupsen/logrus v1.9.3
+)
+
+require (
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
@@ -0,0 +1,52 @@
+// Package camomapstructure provides a mapstructure decode hook that decodes
+// strings into camo Secrets, so that configs decoded by mapstructure, such
+// as those unmarshaled by viper, can hold secrets without intermediate
+// plaintext fields.
+package camomapstructure
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/rbranson/camo"
+)
+
+var (
+	stringSecretType = reflect.TypeFor[camo.Secret[string]]()
+	bytesSecretType  = reflect.TypeFor[camo.Secret[[]byte]]()
+)
+
+// DecodeHook returns a hook that decodes strings and byte slices into
+// camo.Secret[string] and camo.Secret[[]byte] values. Other values are left
+// for mapstructure to decode, so decoding a number into a Secret fails.
+//
+// With viper, pass it to Unmarshal along with viper's default hooks:
+//
+//	err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		camomapstructure.DecodeHook(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//		mapstructure.StringToSliceHookFunc(","),
+//	)))
+func DecodeHook() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if to != stringSecretType && to != bytesSecretType {
+			return data, nil
+		}
+		// Obscure copies the content, so it can be viewed as either type
+		// without copying it first.
+		switch data := data.(type) {
+		case string:
+			if to == bytesSecretType {
+				return camo.Obscure(unsafe.Slice(unsafe.StringData(data), len(data))), nil
+			}
+			return camo.Obscure(data), nil
+		case []byte:
+			if to == stringSecretType {
+				return camo.Obscure(unsafe.String(unsafe.SliceData(data), len(data))), nil
+			}
+			return camo.Obscure(data), nil
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,57 @@
+package camomapstructure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/rbranson/camo"
+	"github.com/spf13/viper"
+)
+
+type config struct {
+	Host     string
+	Password camo.Secret[string]
+	Key      camo.Secret[[]byte]
+}
+
+func TestDecodeHook(t *testing.T) {
+	var cfg config
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: DecodeHook(),
+		Result:     &cfg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dec.Decode(map[string]any{
+		"host":     "db",
+		"password": "hunter2",
+		"key":      []byte("key"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "db" || cfg.Password != camo.Obscure("hunter2") || cfg.Key != camo.Obscure([]byte("key")) {
+		t.Errorf("decoded %+v", cfg)
+	}
+
+	if err := dec.Decode(map[string]any{"password": 42}); err == nil {
+		t.Errorf("expected an error decoding a number into a Secret")
+	}
+}
+
+func TestViper(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader("host: db\npassword: hunter2\nkey: secret-key\n")); err != nil {
+		t.Fatal(err)
+	}
+	var cfg config
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(DecodeHook())); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Password.Reveal() != "hunter2" || string(cfg.Key.Reveal()) != "secret-key" {
+		t.Errorf("decoded %+v", cfg)
+	}
+}
@@ -0,0 +1,34 @@
+package camomapstructure_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/rbranson/camo"
+	"github.com/rbranson/camo/camomapstructure"
+	"github.com/spf13/viper"
+)
+
+func Example_viper() {
+	type Config struct {
+		Host     string
+		Password camo.Secret[string]
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	_ = v.ReadConfig(strings.NewReader("host: db.example.com\npassword: hunter2\n"))
+
+	var cfg Config
+	err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		camomapstructure.DecodeHook(),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(cfg.Host, cfg.Password)
+	// Output: db.example.com camo.Secret[string](REDACTED)
+}
@@ -0,0 +1,179 @@
+// Package camooauth2 adapts golang.org/x/oauth2 to credentials held in camo
+// Secrets.
+//
+// The client secret and refresh token are only revealed for the duration of
+// a token request, and the access token obtained is stored as a Secret. The
+// intended way to use the access token is through Transport or Client, which
+// place it in the Authorization header of each request.
+package camooauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rbranson/camo"
+	"github.com/rbranson/camo/camohttp"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// errNoRefreshToken is returned by token sources for the refresh token grant
+// that have no refresh token.
+var errNoRefreshToken = errors.New("camooauth2: no refresh token")
+
+// Config is an OAuth 2.0 client configuration with the client secret held
+// as a Secret. Its fields mirror those of oauth2.Config. The client secret is
+// zero for public clients, such as those using PKCE.
+type Config struct {
+	ClientID     string
+	ClientSecret camo.Secret[string]
+	Endpoint     oauth2.Endpoint
+	RedirectURL  string
+	Scopes       []string
+}
+
+// oauth2Config returns the equivalent oauth2.Config. It contains the revealed
+// client secret, so it must not be retained.
+func (c *Config) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret.RevealOr(""),
+		Endpoint:     c.Endpoint,
+		RedirectURL:  c.RedirectURL,
+		Scopes:       c.Scopes,
+	}
+}
+
+// TokenSource returns a TokenSource that obtains access tokens with the
+// refresh token grant. If the token endpoint issues a new refresh token, it
+// replaces refreshToken for subsequent requests. If refreshToken is zero,
+// obtaining an access token fails.
+func (c *Config) TokenSource(ctx context.Context, refreshToken camo.Secret[string]) *TokenSource {
+	ts := &TokenSource{}
+	ts.fetch = func() (*oauth2.Token, error) {
+		if !ts.refreshToken.Valid() {
+			return nil, errNoRefreshToken
+		}
+		tok, err := c.oauth2Config().TokenSource(ctx, &oauth2.Token{
+			RefreshToken: ts.refreshToken.Reveal(),
+		}).Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok.RefreshToken != "" {
+			ts.refreshToken = camo.Obscure(tok.RefreshToken)
+		}
+		return tok, nil
+	}
+	ts.refreshToken = refreshToken
+	return ts
+}
+
+// ClientCredentialsTokenSource returns a TokenSource that obtains access
+// tokens with the client credentials grant.
+func (c *Config) ClientCredentialsTokenSource(ctx context.Context) *TokenSource {
+	return &TokenSource{
+		fetch: func() (*oauth2.Token, error) {
+			cc := &clientcredentials.Config{
+				ClientID:     c.ClientID,
+				ClientSecret: c.ClientSecret.RevealOr(""),
+				TokenURL:     c.Endpoint.TokenURL,
+				Scopes:       c.Scopes,
+				AuthStyle:    c.Endpoint.AuthStyle,
+			}
+			return cc.Token(ctx)
+		},
+	}
+}
+
+// expiryDelta matches the margin used by golang.org/x/oauth2, so that tokens
+// are refreshed shortly before they expire rather than used until the last
+// moment.
+const expiryDelta = 10 * time.Second
+
+// TokenSource caches an access token as a Secret, and obtains a new one when
+// it has expired. It is safe for concurrent use.
+type TokenSource struct {
+	fetch func() (*oauth2.Token, error)
+
+	mu           sync.Mutex
+	refreshToken camo.Secret[string]
+	accessToken  camo.Secret[string]
+	tokenType    string
+	expiry       time.Time
+}
+
+// AccessToken returns the current access token and its type, obtaining a new
+// one if it has expired.
+func (ts *TokenSource) AccessToken() (token camo.Secret[string], tokenType string, err error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.accessToken.Valid() && (ts.expiry.IsZero() || time.Now().Add(expiryDelta).Before(ts.expiry)) {
+		return ts.accessToken, ts.tokenType, nil
+	}
+	tok, err := ts.fetch()
+	if err != nil {
+		return camo.Secret[string]{}, "", err
+	}
+	ts.accessToken = camo.Obscure(tok.AccessToken)
+	ts.tokenType = tok.Type()
+	ts.expiry = tok.Expiry
+	return ts.accessToken, ts.tokenType, nil
+}
+
+// Token implements oauth2.TokenSource for compatibility with code that
+// requires one. The returned token contains the revealed access token, so
+// prefer Transport or Client where possible. The refresh token is never
+// included.
+func (ts *TokenSource) Token() (*oauth2.Token, error) {
+	token, tokenType, err := ts.AccessToken()
+	if err != nil {
+		return nil, err
+	}
+	ts.mu.Lock()
+	expiry := ts.expiry
+	ts.mu.Unlock()
+	return &oauth2.Token{
+		AccessToken: token.Reveal(),
+		TokenType:   tokenType,
+		Expiry:      expiry,
+	}, nil
+}
+
+// Transport returns an http.RoundTripper that authorizes every request made
+// through base with the access token from ts. If base is nil,
+// http.DefaultTransport is used.
+func (ts *TokenSource) Transport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base, ts: ts}
+}
+
+// Client returns an *http.Client that authorizes every request with the
+// access token from ts.
+func (ts *TokenSource) Client() *http.Client {
+	return &http.Client{Transport: ts.Transport(nil)}
+}
+
+type transport struct {
+	base http.RoundTripper
+	ts   *TokenSource
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, tokenType, err := t.ts.AccessToken()
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	inner := &camohttp.Transport{
+		Base:   t.base,
+		Scheme: tokenType,
+		Secret: func() camo.Secret[string] { return token },
+	}
+	return inner.RoundTrip(req)
+}
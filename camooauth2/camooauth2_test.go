@@ -0,0 +1,176 @@
+package camooauth2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"golang.org/x/oauth2"
+)
+
+func newTokenServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		user, pass, _ := r.BasicAuth()
+		if user != "id" || pass != "client-secret" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		resp := map[string]any{"token_type": "Bearer", "expires_in": 3600}
+		switch r.Form.Get("grant_type") {
+		case "client_credentials":
+			resp["access_token"] = "cc-token"
+		case "refresh_token":
+			want := "refresh-1"
+			if n > 1 {
+				want = "refresh-2"
+			}
+			if got := r.Form.Get("refresh_token"); got != want {
+				t.Errorf("refresh_token = %q; want %q", got, want)
+			}
+			resp["access_token"] = "rt-token"
+			resp["refresh_token"] = "refresh-2"
+			resp["expires_in"] = 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestClientCredentials(t *testing.T) {
+	srv, requests := newTokenServer(t)
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: camo.Obscure("client-secret"),
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+	}
+	ts := c.ClientCredentialsTokenSource(context.Background())
+
+	for i := 0; i < 2; i++ {
+		token, tokenType, err := ts.AccessToken()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := token.Reveal(); got != "cc-token" {
+			t.Errorf("got = %q; want %q", got, "cc-token")
+		}
+		if tokenType != "Bearer" {
+			t.Errorf("tokenType = %q; want %q", tokenType, "Bearer")
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d token requests; want 1", n)
+	}
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	srv, requests := newTokenServer(t)
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: camo.Obscure("client-secret"),
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+	}
+	ts := c.TokenSource(context.Background(), camo.Obscure("refresh-1"))
+
+	// The token expires within expiryDelta, so each call refreshes.
+	for i := 0; i < 2; i++ {
+		tok, err := ts.Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tok.AccessToken != "rt-token" {
+			t.Errorf("got = %q; want %q", tok.AccessToken, "rt-token")
+		}
+		if tok.RefreshToken != "" {
+			t.Errorf("refresh token should not be exposed")
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("got %d token requests; want 2", n)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	tokenSrv, _ := newTokenServer(t)
+	var got string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+	}))
+	t.Cleanup(api.Close)
+
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: camo.Obscure("client-secret"),
+		Endpoint:     oauth2.Endpoint{TokenURL: tokenSrv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+	}
+	resp, err := c.ClientCredentialsTokenSource(context.Background()).Client().Get(api.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if want := "Bearer cc-token"; got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	srv, _ := newTokenServer(t)
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: camo.Obscure("wrong"),
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+	}
+	if _, _, err := c.ClientCredentialsTokenSource(context.Background()).AccessToken(); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestPublicClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if id, secret := r.Form.Get("client_id"), r.Form.Get("client_secret"); id != "public" || secret != "" {
+			t.Errorf("got client %q with secret %q; want %q without a secret", id, secret, "public")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "public-token", "token_type": "Bearer"})
+	}))
+	defer srv.Close()
+	c := &Config{
+		ClientID: "public",
+		Endpoint: oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+	token, _, err := c.TokenSource(context.Background(), camo.Obscure("refresh-1")).AccessToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := token.Reveal(); got != "public-token" {
+		t.Errorf("got = %q; want %q", got, "public-token")
+	}
+}
+
+func TestNoRefreshToken(t *testing.T) {
+	srv, requests := newTokenServer(t)
+	c := &Config{
+		ClientID:     "id",
+		ClientSecret: camo.Obscure("client-secret"),
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInHeader},
+	}
+	if _, _, err := c.TokenSource(context.Background(), camo.Secret[string]{}).AccessToken(); err != errNoRefreshToken {
+		t.Errorf("got err = %v; want %v", err, errNoRefreshToken)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d token requests; want 0", n)
+	}
+}
@@ -0,0 +1,75 @@
+// Package camoop provides a camo.Provider that resolves 1Password secret
+// references, such as "op://vault/item/field", with the op CLI.
+package camoop
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/rbranson/camo"
+)
+
+// Provider is a camo.Provider that runs "op read" to resolve secret
+// references. Names are secret references, with or without the "op://"
+// prefix. The op CLI must be signed in, or OP_SERVICE_ACCOUNT_TOKEN must be
+// set in the environment.
+type Provider struct {
+	// Command is the path of the op CLI. It defaults to "op".
+	Command string
+
+	// Account selects the account to use, when signed in to several.
+	Account string
+}
+
+// Get resolves the secret reference name.
+func (p Provider) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	ref := name
+	if !strings.HasPrefix(ref, "op://") {
+		ref = "op://" + ref
+	}
+	command := p.Command
+	if command == "" {
+		command = "op"
+	}
+	args := []string{"read", "--no-newline"}
+	if p.Account != "" {
+		args = append(args, "--account", p.Account)
+	}
+	args = append(args, ref)
+	cmd := exec.CommandContext(ctx, command, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	out := stdout.Bytes()
+	if err != nil {
+		clear(out)
+		msg := strings.TrimSpace(stderr.String())
+		if isNotFound(msg) {
+			return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && msg != "" {
+			return camo.Secret[string]{}, fmt.Errorf("camoop: reading %q: %s", name, msg)
+		}
+		return camo.Secret[string]{}, fmt.Errorf("camoop: reading %q: %w", name, err)
+	}
+	return camo.ObscureOwned(out).AsString(), nil
+}
+
+// isNotFound reports if the error message printed by op is for a reference
+// to something that doesn't exist.
+func isNotFound(msg string) bool {
+	for _, s := range []string{"isn't an item", "isn't a vault", "isn't a field", "could not be found", "does not have a field"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ camo.Provider = Provider{}
@@ -0,0 +1,66 @@
+package camoop
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+// fakeOp writes a script that behaves like "op read" for a single item.
+func fakeOp(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	path := filepath.Join(t.TempDir(), "op")
+	script := `#!/bin/sh
+[ "$1" = read ] && [ "$2" = --no-newline ] || { echo "unexpected arguments" >&2; exit 2; }
+shift 2
+if [ "$1" = --account ]; then
+	[ "$2" = work ] || { echo "[ERROR] no account found" >&2; exit 1; }
+	shift 2
+fi
+case "$1" in
+op://dev/db/password) printf hunter2 ;;
+op://dev/db/*) echo "[ERROR] item 'dev/db' does not have a field" >&2; exit 1 ;;
+*) echo "[ERROR] \"$1\" isn't an item" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProvider(t *testing.T) {
+	p := Provider{Command: fakeOp(t)}
+	ctx := context.Background()
+	for _, name := range []string{"op://dev/db/password", "dev/db/password"} {
+		s, err := p.Get(ctx, name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != "hunter2" {
+			t.Errorf("Get(%q) = %q; want %q", name, got, "hunter2")
+		}
+	}
+	for _, name := range []string{"dev/missing/password", "dev/db/missing"} {
+		if _, err := p.Get(ctx, name); !errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want camo.ErrNotFound", name, err)
+		}
+	}
+
+	p.Account = "personal"
+	if _, err := p.Get(ctx, "dev/db/password"); err == nil || errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want an account error", err)
+	}
+	p.Account = "work"
+	if _, err := p.Get(ctx, "dev/db/password"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Package camopgx teaches pgx v5 to bind camo.Secret values as query
+// parameters.
+//
+// Scanning into a camo.Secret works without this package, because Secret
+// implements sql.Scanner. Binding a Secret as a parameter requires calling
+// Register on the connection's type map, typically from an AfterConnect hook:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		camopgx.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// A zero Secret is bound as NULL.
+package camopgx
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rbranson/camo"
+)
+
+// Register adds the encode plans for camo.Secret[string] and
+// camo.Secret[[]byte] to m. They take priority over pgx's own wrappers, which
+// would otherwise try to encode a Secret as a composite type.
+func Register(m *pgtype.Map) {
+	m.TryWrapEncodePlanFuncs = append(
+		[]pgtype.TryWrapEncodePlanFunc{TryWrapSecretEncodePlan},
+		m.TryWrapEncodePlanFuncs...,
+	)
+}
+
+// TryWrapSecretEncodePlan is a pgtype.TryWrapEncodePlanFunc that converts
+// camo.Secret[string] values into a pgtype.TextValuer and camo.Secret[[]byte]
+// values into a pgtype.BytesValuer.
+func TryWrapSecretEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	switch value := value.(type) {
+	case camo.Secret[string]:
+		return &wrapSecretStringEncodePlan{}, secretStringWrapper{s: value}, true
+	case camo.Secret[[]byte]:
+		return &wrapSecretBytesEncodePlan{}, secretBytesWrapper(nil), true
+	}
+	return nil, nil, false
+}
+
+type wrapSecretStringEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapSecretStringEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapSecretStringEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(secretStringWrapper{s: value.(camo.Secret[string])}, buf)
+}
+
+type secretStringWrapper struct {
+	s camo.Secret[string]
+}
+
+func (w secretStringWrapper) TextValue() (pgtype.Text, error) {
+	if !w.s.Valid() {
+		return pgtype.Text{}, nil
+	}
+	return pgtype.Text{String: w.s.Reveal(), Valid: true}, nil
+}
+
+type wrapSecretBytesEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapSecretBytesEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapSecretBytesEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	s := value.(camo.Secret[[]byte])
+	if !s.Valid() {
+		return plan.next.Encode(secretBytesWrapper(nil), buf)
+	}
+	// The revealed copy only needs to live until it has been appended to buf.
+	content := s.Reveal()
+	defer clear(content)
+	return plan.next.Encode(secretBytesWrapper(content), buf)
+}
+
+type secretBytesWrapper []byte
+
+func (w secretBytesWrapper) BytesValue() ([]byte, error) {
+	return w, nil
+}
@@ -0,0 +1,78 @@
+package camopgx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rbranson/camo"
+)
+
+func TestEncodeString(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.TextOID, format, camo.Obscure("hunter2"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(buf) != "hunter2" {
+			t.Errorf("format %d: got = %q; want %q", format, buf, "hunter2")
+		}
+	}
+}
+
+func TestEncodeBytes(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	buf, err := m.Encode(pgtype.ByteaOID, pgtype.BinaryFormatCode, camo.Obscure([]byte{1, 2, 3}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(buf, []byte{1, 2, 3}) {
+		t.Errorf("got = %v; want %v", buf, []byte{1, 2, 3})
+	}
+}
+
+func TestEncodeZeroAsNull(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	buf, err := m.Encode(pgtype.TextOID, pgtype.TextFormatCode, camo.Secret[string]{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf != nil {
+		t.Errorf("got = %q; want NULL", buf)
+	}
+
+	buf, err = m.Encode(pgtype.ByteaOID, pgtype.BinaryFormatCode, camo.Secret[[]byte]{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf != nil {
+		t.Errorf("got = %v; want NULL", buf)
+	}
+}
+
+func TestScan(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var s camo.Secret[string]
+	if err := m.Scan(pgtype.TextOID, pgtype.TextFormatCode, []byte("hunter2"), &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+
+	var b camo.Secret[[]byte]
+	if err := m.Scan(pgtype.ByteaOID, pgtype.BinaryFormatCode, []byte{1, 2, 3}, &b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := b.Reveal(); !bytes.Equal(got, []byte{1, 2, 3}) {
+		t.Errorf("got = %v; want %v", got, []byte{1, 2, 3})
+	}
+}
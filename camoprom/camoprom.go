@@ -0,0 +1,59 @@
+// Package camoprom exports the camo Stats as Prometheus metrics.
+package camoprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rbranson/camo"
+)
+
+var (
+	revealsDesc = prometheus.NewDesc(
+		"camo_reveals_total",
+		"Number of times the content of a secret was extracted.",
+		nil, nil,
+	)
+	appendsDesc = prometheus.NewDesc(
+		"camo_appends_total",
+		"Number of times the content of a secret was appended to a byte slice.",
+		nil, nil,
+	)
+	zeroPanicsDesc = prometheus.NewDesc(
+		"camo_zero_panics_total",
+		"Number of panics caused by using the content of a zero secret.",
+		nil, nil,
+	)
+	scrubHitsDesc = prometheus.NewDesc(
+		"camo_scrub_hits_total",
+		"Number of times the content of a registered secret was scrubbed from output.",
+		nil, nil,
+	)
+)
+
+// Collector is a prometheus.Collector for the camo Stats.
+type Collector struct{}
+
+// NewCollector returns a Collector, which can be registered with:
+//
+//	prometheus.MustRegister(camoprom.NewCollector())
+func NewCollector() Collector {
+	return Collector{}
+}
+
+// Describe implements prometheus.Collector.
+func (Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- revealsDesc
+	ch <- appendsDesc
+	ch <- zeroPanicsDesc
+	ch <- scrubHitsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (Collector) Collect(ch chan<- prometheus.Metric) {
+	s := camo.ReadStats()
+	ch <- prometheus.MustNewConstMetric(revealsDesc, prometheus.CounterValue, float64(s.Reveals))
+	ch <- prometheus.MustNewConstMetric(appendsDesc, prometheus.CounterValue, float64(s.Appends))
+	ch <- prometheus.MustNewConstMetric(zeroPanicsDesc, prometheus.CounterValue, float64(s.ZeroPanics))
+	ch <- prometheus.MustNewConstMetric(scrubHitsDesc, prometheus.CounterValue, float64(s.ScrubHits))
+}
+
+var _ prometheus.Collector = Collector{}
@@ -0,0 +1,46 @@
+package camoprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rbranson/camo"
+)
+
+func TestCollector(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(NewCollector()); err != nil {
+		t.Fatal(err)
+	}
+	camo.Obscure("hunter2").Reveal()
+	n, err := testutil.GatherAndCount(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Errorf("gathered %d metrics; want 4", n)
+	}
+	want := `
+# HELP camo_zero_panics_total Number of panics caused by using the content of a zero secret.
+# TYPE camo_zero_panics_total counter
+camo_zero_panics_total 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "camo_zero_panics_total"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.ToFloat64(revealsCollector{}); got < 1 {
+		t.Errorf("camo_reveals_total = %v; want at least 1", got)
+	}
+}
+
+// revealsCollector collects only camo_reveals_total, as testutil.ToFloat64
+// requires a single metric.
+type revealsCollector struct{}
+
+func (revealsCollector) Describe(ch chan<- *prometheus.Desc) { ch <- revealsDesc }
+
+func (revealsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(revealsDesc, prometheus.CounterValue, float64(camo.ReadStats().Reveals))
+}
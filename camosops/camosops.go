@@ -0,0 +1,124 @@
+// Package camosops decrypts SOPS-encrypted files into camo Secrets, so that
+// encrypted configuration can be consumed without its plaintext ending up in
+// ordinary maps or structs.
+package camosops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rbranson/camo"
+)
+
+// File holds the values of a decrypted file as Secrets, keyed by their path
+// in the document, with the keys of nested objects and the indexes of arrays
+// joined by dots, such as "db.password" or "hosts.0". Numbers and booleans
+// are held in their JSON form, and nulls are omitted.
+//
+// File is a camo.Provider that resolves names as paths.
+type File struct {
+	values map[string]camo.Secret[string]
+}
+
+// Decrypt decrypts the SOPS-encrypted YAML, JSON, dotenv or INI file at path
+// by running "sops --decrypt --output-type json". The sops command must be
+// in the PATH, and have access to the keys the file is encrypted with.
+func Decrypt(ctx context.Context, path string) (*File, error) {
+	cmd := exec.CommandContext(ctx, "sops", "--decrypt", "--output-type", "json", path)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	out := stdout.Bytes()
+	defer clear(out)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); errors.As(err, &exitErr) && msg != "" {
+			return nil, fmt.Errorf("camosops: decrypting %s: %s", path, msg)
+		}
+		return nil, fmt.Errorf("camosops: decrypting %s: %w", path, err)
+	}
+	return Parse(bytes.NewReader(out))
+}
+
+// Parse reads a decrypted document in JSON form.
+func Parse(r io.Reader) (*File, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	f := &File{values: make(map[string]camo.Secret[string])}
+	if err := f.parse(dec, ""); err != nil {
+		return nil, fmt.Errorf("camosops: parsing decrypted document: %w", err)
+	}
+	return f, nil
+}
+
+// parse reads the value at path from dec, obscuring each scalar as soon as
+// it has been read.
+func (f *File) parse(dec *json.Decoder, path string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		for i := 0; dec.More(); i++ {
+			key := strconv.Itoa(i)
+			if tok == '{' {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key = keyTok.(string)
+			}
+			if err := f.parse(dec, join(key)); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter.
+		_, err := dec.Token()
+		return err
+	case string:
+		f.values[path] = camo.Obscure(tok)
+	case json.Number:
+		f.values[path] = camo.Obscure(tok.String())
+	case bool:
+		f.values[path] = camo.Obscure(strconv.FormatBool(tok))
+	case nil:
+	}
+	return nil
+}
+
+// Get returns the value at the path name.
+func (f *File) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	s, ok := f.values[name]
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	return s, nil
+}
+
+// Keys returns the paths of all values in the file, sorted.
+func (f *File) Keys() []string {
+	keys := make([]string, 0, len(f.values))
+	for k := range f.values {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+var _ camo.Provider = (*File)(nil)
@@ -0,0 +1,88 @@
+package camosops
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+const decrypted = `{
+	"db": {"user": "admin", "password": "hunter2", "port": 5432, "tls": true},
+	"hosts": ["a", "b"],
+	"empty": null
+}`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(decrypted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"db.user":     "admin",
+		"db.password": "hunter2",
+		"db.port":     "5432",
+		"db.tls":      "true",
+		"hosts.0":     "a",
+		"hosts.1":     "b",
+	}
+	if got := strings.Join(f.Keys(), ","); got != "db.password,db.port,db.tls,db.user,hosts.0,hosts.1" {
+		t.Errorf("Keys() = %s", got)
+	}
+	for name, value := range want {
+		s, err := f.Get(context.Background(), name)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", name, err)
+		}
+		if got := s.Reveal(); got != value {
+			t.Errorf("Get(%q) = %q; want %q", name, got, value)
+		}
+	}
+	for _, name := range []string{"db", "empty", "missing"} {
+		if _, err := f.Get(context.Background(), name); !errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want camo.ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader(`{"a": `)); err == nil {
+		t.Errorf("expected an error for a truncated document")
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		`[ "$1 $2 $3 $4" = "--decrypt --output-type json secrets.enc.yaml" ] || { echo "Error: no key could decrypt the data key" >&2; exit 128; }` + "\n" +
+		"echo '" + decrypted + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, "sops"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	f, err := Decrypt(context.Background(), "secrets.enc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := f.Get(context.Background(), "db.password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+
+	_, err = Decrypt(context.Background(), "other.enc.yaml")
+	if err == nil || !strings.Contains(err.Error(), "no key could decrypt") {
+		t.Errorf("err = %v; want the error printed by sops", err)
+	}
+}
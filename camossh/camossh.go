@@ -0,0 +1,27 @@
+// Package camossh creates SSH signers from private keys held in camo
+// Secrets, so that key material is only revealed when it is parsed.
+package camossh
+
+import (
+	"github.com/rbranson/camo"
+	"golang.org/x/crypto/ssh"
+)
+
+// NewSignerFromSecret parses a PEM encoded private key and returns a Signer
+// for it. If passphrase is a zero or empty Secret, the key must not be
+// encrypted, otherwise it is decrypted with passphrase. The revealed copies
+// of the key and passphrase are wiped once parsed.
+func NewSignerFromSecret(key camo.Secret[[]byte], passphrase camo.Secret[[]byte]) (ssh.Signer, error) {
+	pemBytes := key.Reveal()
+	defer clear(pemBytes)
+
+	if !passphrase.Valid() {
+		return ssh.ParsePrivateKey(pemBytes)
+	}
+	pass := passphrase.Reveal()
+	defer clear(pass)
+	if len(pass) == 0 {
+		return ssh.ParsePrivateKey(pemBytes)
+	}
+	return ssh.ParsePrivateKeyWithPassphrase(pemBytes, pass)
+}
@@ -0,0 +1,63 @@
+package camossh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewSignerFromSecret(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		key        *pem.Block
+		passphrase camo.Secret[[]byte]
+		wantErr    bool
+	}{
+		{name: "unencrypted", key: plain},
+		{name: "unencrypted with empty passphrase", key: plain, passphrase: camo.Obscure([]byte{})},
+		{name: "encrypted", key: encrypted, passphrase: camo.Obscure([]byte("pass"))},
+		{name: "encrypted with wrong passphrase", key: encrypted, passphrase: camo.Obscure([]byte("nope")), wantErr: true},
+		{name: "encrypted without passphrase", key: encrypted, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := camo.Obscure(pem.EncodeToMemory(tc.key))
+			signer, err := NewSignerFromSecret(key, tc.passphrase)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(signer.PublicKey().Marshal(), sshPub.Marshal()) {
+				t.Errorf("signer has the wrong public key")
+			}
+		})
+	}
+}
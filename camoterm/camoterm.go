@@ -0,0 +1,50 @@
+// Package camoterm reads passwords from a terminal into camo Secrets, without
+// the password ever being held in a plain string.
+package camoterm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rbranson/camo"
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal is returned when the input is not a terminal.
+var ErrNotTerminal = errors.New("camoterm: input is not a terminal")
+
+// Prompt writes prompt to standard error and reads a line from the terminal
+// on standard input with echo disabled.
+func Prompt(prompt string) (camo.Secret[string], error) {
+	return PromptFrom(os.Stdin, os.Stderr, prompt)
+}
+
+// PromptFrom writes prompt to out and reads a line from the terminal in with
+// echo disabled. As the newline typed by the user isn't echoed, one is
+// written to out after the line is read.
+func PromptFrom(in *os.File, out io.Writer, prompt string) (camo.Secret[string], error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return camo.Secret[string]{}, ErrNotTerminal
+	}
+	if _, err := io.WriteString(out, prompt); err != nil {
+		return camo.Secret[string]{}, err
+	}
+	s, err := ReadPassword(fd)
+	fmt.Fprintln(out)
+	return s, err
+}
+
+// ReadPassword reads a line from the terminal fd with echo disabled, like
+// term.ReadPassword. The buffer the line is read into becomes the content of
+// the Secret, so it isn't copied again.
+func ReadPassword(fd int) (camo.Secret[string], error) {
+	password, err := term.ReadPassword(fd)
+	if err != nil {
+		clear(password)
+		return camo.Secret[string]{}, err
+	}
+	return camo.ObscureOwned(password).AsString(), nil
+}
@@ -0,0 +1,25 @@
+package camoterm
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestPromptFromNotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	var out bytes.Buffer
+	_, err = PromptFrom(r, &out, "Password: ")
+	if !errors.Is(err, ErrNotTerminal) {
+		t.Errorf("err = %v; want ErrNotTerminal", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no prompt to be written, got %q", out.String())
+	}
+}
@@ -0,0 +1,65 @@
+// Package camotest provides helpers for testing code that uses camo
+// Secrets. The assertions report failures without the content of the
+// secrets, so that tests don't print them when they fail.
+package camotest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+// WithSeed makes the hashes of the content of Secrets created until the end
+// of the test deterministic, as with camo.SetHashSeed, so that tests and
+// golden files that depend on them, such as through the order of maps keyed
+// by Secrets or Hash64, are reproducible. Secrets created before or after
+// the test keep their hashes, so they don't compare equal to those created
+// during it.
+//
+// The hash function is process-wide, so like testing.T.Setenv, which it
+// uses to enforce this, WithSeed panics in parallel tests and in tests whose
+// ancestors are parallel.
+func WithSeed(t testing.TB, seed uint64) {
+	t.Helper()
+	t.Setenv("CAMOTEST_SEED", strconv.FormatUint(seed, 10))
+	t.Cleanup(camo.SetHashSeed(seed))
+}
+
+// Equal reports an error if the content of got isn't want, or if got is
+// zero. The failure message includes the lengths and fingerprints (see
+// camo.Secret.Fingerprint) of the two, but not their content.
+func Equal[O camo.Obscurable](t testing.TB, got camo.Secret[O], want string) bool {
+	t.Helper()
+	if !got.Valid() {
+		t.Errorf("got a zero Secret, want one with content of length %d", len(want))
+		return false
+	}
+	w := camo.Obscure(want)
+	if !camo.ContentEqual(got, w) {
+		t.Errorf("Secret content differs: got length %d, fingerprint %s; want length %d, fingerprint %s", got.Len(), got.Fingerprint(), w.Len(), w.Fingerprint())
+		return false
+	}
+	return true
+}
+
+// Empty reports an error if s isn't zero and its content isn't empty.
+func Empty[O camo.Obscurable](t testing.TB, s camo.Secret[O]) bool {
+	t.Helper()
+	if !s.IsEmpty() {
+		t.Errorf("got a Secret with content of length %d, fingerprint %s; want an empty one", s.Len(), s.Fingerprint())
+		return false
+	}
+	return true
+}
+
+// RevealForTest returns the content of s, or ends the test if s is zero
+// instead of panicking, so that tests can inspect the content where the
+// assertions in this package aren't enough.
+func RevealForTest[O camo.Obscurable](t testing.TB, s camo.Secret[O]) O {
+	t.Helper()
+	if !s.Valid() {
+		t.Fatalf("RevealForTest: got a zero Secret")
+	}
+	return s.Reveal()
+}
@@ -0,0 +1,113 @@
+package camotest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+func TestWithSeed(t *testing.T) {
+	before := camo.Obscure("hunter2")
+	var got uint64
+	t.Run("seeded", func(t *testing.T) {
+		WithSeed(t, 42)
+		got = camo.Obscure("hunter2").Hash64()
+		// SipHashed keys k0 and k1 with the two little-endian halves of the
+		// key, and WithSeed uses the seed as k0 and 0 as k1.
+		key := camo.Obscure([]byte{42, 15: 0})
+		if want := camo.Obscure("hunter2", camo.SipHashed(key)).Hash64(); got != want {
+			t.Errorf("Hash64() = %x; want %x", got, want)
+		}
+		if camo.Obscure([]byte("hunter2")).Hash64() != got {
+			t.Errorf("expected the same hash for the same content")
+		}
+		if camo.ObscureSealed("hunter2") != camo.Obscure("hunter2").Seal() {
+			t.Errorf("expected sealed secrets to be hashed with the seed")
+		}
+	})
+	if after := camo.Obscure("hunter2"); after != before || after.Hash64() == got {
+		t.Errorf("expected the default hash to be restored")
+	}
+
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected WithSeed() to panic in a parallel test")
+			}
+		}()
+		WithSeed(t, 42)
+	})
+}
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	msgs  []string
+	fatal bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.msgs = append(r.msgs, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	runtime.Goexit()
+}
+
+// record runs f with a recorder in its own goroutine, so that Fatalf can
+// end it.
+func record(t *testing.T, f func(tb testing.TB)) *recorder {
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(r)
+	}()
+	<-done
+	return r
+}
+
+func TestEqual(t *testing.T) {
+	s := camo.Obscure([]byte("hunter2"))
+	if r := record(t, func(tb testing.TB) { Equal(tb, s, "hunter2") }); len(r.msgs) != 0 {
+		t.Errorf("unexpected failure: %v", r.msgs)
+	}
+	r := record(t, func(tb testing.TB) { Equal(tb, s, "hunter3") })
+	if len(r.msgs) != 1 || strings.Contains(r.msgs[0], "hunter") {
+		t.Errorf("got failures %q", r.msgs)
+	}
+	r = record(t, func(tb testing.TB) { Equal(tb, camo.Secret[string]{}, "") })
+	if len(r.msgs) != 1 {
+		t.Errorf("expected a zero Secret to fail")
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	for _, s := range []camo.Secret[string]{{}, camo.Obscure("")} {
+		if r := record(t, func(tb testing.TB) { Empty(tb, s) }); len(r.msgs) != 0 {
+			t.Errorf("unexpected failure: %v", r.msgs)
+		}
+	}
+	r := record(t, func(tb testing.TB) { Empty(tb, camo.Obscure("hunter2")) })
+	if len(r.msgs) != 1 || strings.Contains(r.msgs[0], "hunter2") {
+		t.Errorf("got failures %q", r.msgs)
+	}
+}
+
+func TestRevealForTest(t *testing.T) {
+	if got := RevealForTest(t, camo.Obscure("hunter2")); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	r := record(t, func(tb testing.TB) { RevealForTest(tb, camo.Secret[[]byte]{}) })
+	if !r.fatal {
+		t.Errorf("expected a zero Secret to end the test")
+	}
+}
@@ -0,0 +1,37 @@
+package camotest
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rbranson/camo"
+)
+
+// Comparer returns a cmp.Option that compares Secret[string] and
+// Secret[[]byte] values by their content with camo.ContentEqual, which
+// otherwise can't be compared by cmp as their fields are unexported.
+// Differing Secrets are rendered in diffs with their lengths and
+// fingerprints (see camo.Secret.Fingerprint) instead of their content.
+func Comparer() cmp.Option {
+	return cmp.Options{secretOption[string](), secretOption[[]byte]()}
+}
+
+func secretOption[O camo.Obscurable]() cmp.Option {
+	// Equal Secrets are ignored, and the others are transformed into their
+	// redacted descriptions, which differ, so that the diff shows them
+	// instead of the fields of the Secrets.
+	return cmp.Options{
+		cmp.FilterValues(camo.ContentEqual[O, O], cmp.Ignore()),
+		cmp.FilterValues(func(a, b camo.Secret[O]) bool {
+			return !camo.ContentEqual(a, b)
+		}, cmp.Transformer("camo.Redact", describe[O])),
+	}
+}
+
+// describe returns a description of s that doesn't include its content.
+func describe[O camo.Obscurable](s camo.Secret[O]) string {
+	if !s.Valid() {
+		return fmt.Sprintf("%T(zero)", s)
+	}
+	return fmt.Sprintf("%v (length %d, fingerprint %s)", s, s.Len(), s.Fingerprint())
+}
@@ -0,0 +1,40 @@
+package camotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rbranson/camo"
+)
+
+type config struct {
+	Host     string
+	Password camo.Secret[string]
+	Key      camo.Secret[[]byte]
+}
+
+func TestComparer(t *testing.T) {
+	a := config{Host: "db", Password: camo.Obscure("hunter2"), Key: camo.Obscure([]byte("key"))}
+	b := config{Host: "db", Password: camo.Obscure("hunter2", camo.Unhashed()), Key: camo.Obscure([]byte("key"))}
+	if diff := cmp.Diff(a, b, Comparer()); diff != "" {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+
+	b.Password = camo.Obscure("hunter3")
+	diff := cmp.Diff(a, b, Comparer())
+	if diff == "" {
+		t.Errorf("expected a diff")
+	}
+	if strings.Contains(diff, "hunter") || !strings.Contains(diff, "REDACTED") {
+		t.Errorf("diff isn't redacted:\n%s", diff)
+	}
+
+	b.Password = camo.Secret[string]{}
+	if diff := cmp.Diff(a, b, Comparer()); !strings.Contains(diff, "zero") {
+		t.Errorf("expected the diff to show a zero Secret:\n%s", diff)
+	}
+	if !cmp.Equal(config{}, config{}, Comparer()) {
+		t.Errorf("expected zero Secrets to be equal")
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/rbranson/camo/camotest
+
+go 1.24
+
+require (
+	github.com/google/go-cmp v0.7.0
+	github.com/rbranson/camo v0.0.0-20261016170954-35c34b5a793c
+	pgregory.net/rapid v1.2.0
+)
+
+require (
+	golang.org/x/crypto v0.40.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+)
@@ -0,0 +1,65 @@
+package camotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+var update = flag.Bool("camotest.update", false, "update the golden files of camotest.Golden")
+
+// Golden checks that v doesn't leak the content of registered secrets (see
+// camo.Register), and that its redacted form matches a golden file, as a
+// regression test for the redaction of a type. It reports an error if the
+// content of a registered secret appears when v is formatted with fmt's %v,
+// %+v, or %#v, marshaled to JSON, or redacted, and if the JSON of camo.Redact
+// of v differs from the file named name plus ".golden" in the testdata
+// directory. Run the test with -camotest.update to write the file instead.
+func Golden(t testing.TB, name string, v any) {
+	t.Helper()
+	redacted, err := json.MarshalIndent(camo.Redact(v), "", "\t")
+	if err != nil {
+		t.Fatalf("Golden: marshaling the redacted value: %v", err)
+	}
+	redacted = append(redacted, '\n')
+
+	outputs := map[string]string{
+		"%v":       fmt.Sprintf("%v", v),
+		"%+v":      fmt.Sprintf("%+v", v),
+		"%#v":      fmt.Sprintf("%#v", v),
+		"redacted": string(redacted),
+	}
+	if b, err := json.Marshal(v); err == nil {
+		outputs["JSON"] = string(b)
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "JSON", "redacted"} {
+		out, ok := outputs[format]
+		if ok && camo.Scrub(out) != out {
+			t.Errorf("Golden: %s form of %T contains a registered secret: %s", format, v, camo.Scrub(out))
+		}
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, redacted, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Golden: %v (run with -camotest.update to create it)", err)
+	}
+	if !bytes.Equal(redacted, want) {
+		t.Errorf("Golden: redacted form of %T differs from %s:\ngot:\n%s\nwant:\n%s", v, path, redacted, want)
+	}
+}
@@ -0,0 +1,43 @@
+package camotest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rbranson/camo"
+)
+
+type request struct {
+	User    string
+	Token   camo.Secret[string]
+	Headers map[string]string
+}
+
+func TestGolden(t *testing.T) {
+	token := camo.Obscure("camotest-golden-token")
+	camo.Register(token)
+	t.Cleanup(func() { camo.Unregister(token) })
+
+	Golden(t, "request", request{
+		User:    "alice",
+		Token:   token,
+		Headers: map[string]string{"Accept": "application/json"},
+	})
+}
+
+func TestGoldenLeak(t *testing.T) {
+	token := camo.Obscure("camotest-golden-leak")
+	camo.Register(token)
+	t.Cleanup(func() { camo.Unregister(token) })
+
+	leaky := request{User: "alice", Headers: map[string]string{"Authorization": "camotest-golden-leak"}}
+	r := record(t, func(tb testing.TB) { Golden(tb, "request", leaky) })
+	if len(r.msgs) == 0 {
+		t.Fatalf("expected a leak to be reported")
+	}
+	for _, msg := range r.msgs {
+		if strings.Contains(msg, "camotest-golden-leak") {
+			t.Errorf("failure message contains the secret: %s", msg)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package camotest
+
+import (
+	"github.com/rbranson/camo"
+	"pgregory.net/rapid"
+)
+
+// Secrets returns a rapid generator of valid Secrets with arbitrary content,
+// for property-based tests using rapid. As with any value drawn by rapid,
+// the content is printed when a property fails.
+func Secrets[O camo.Obscurable]() *rapid.Generator[camo.Secret[O]] {
+	return rapid.Custom(func(t *rapid.T) camo.Secret[O] {
+		return camo.Obscure(O(rapid.SliceOf(rapid.Byte()).Draw(t, "content")))
+	})
+}
@@ -0,0 +1,21 @@
+package camotest
+
+import (
+	"testing"
+
+	"github.com/rbranson/camo"
+	"pgregory.net/rapid"
+)
+
+func TestSecrets(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := Secrets[string]().Draw(t, "s")
+		b := Secrets[[]byte]().Draw(t, "b")
+		if !s.Valid() || !b.Valid() {
+			t.Fatalf("expected valid Secrets")
+		}
+		if camo.ContentEqual(s, b) != (s.AsBytes() == b) {
+			t.Fatalf("expected ContentEqual to agree with ==")
+		}
+	})
+}
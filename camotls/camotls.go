@@ -0,0 +1,53 @@
+// Package camotls loads TLS key pairs whose private keys are held in camo
+// Secrets, so that key material is only revealed when it is handed to
+// crypto/tls.
+package camotls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"sync"
+
+	"github.com/rbranson/camo"
+)
+
+// X509KeyPair parses a public/private key pair from PEM encoded data, like
+// tls.X509KeyPair. The revealed copy of the key is wiped once parsed.
+func X509KeyPair(certPEM []byte, key camo.Secret[[]byte]) (tls.Certificate, error) {
+	keyPEM := key.Reveal()
+	defer clear(keyPEM)
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// GetCertificate returns a function for use as tls.Config.GetCertificate
+// that serves the key pair returned by load. The load function is called on
+// every handshake so that a reloaded key pair is picked up, but the key pair
+// is only parsed again when the certificate or the key has changed.
+func GetCertificate(load func() (certPEM []byte, key camo.Secret[[]byte], err error)) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	var (
+		mu      sync.Mutex
+		lastPEM []byte
+		lastKey camo.Secret[[]byte]
+		cert    *tls.Certificate
+	)
+	return func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		certPEM, key, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if cert != nil && key == lastKey && bytes.Equal(certPEM, lastPEM) {
+			return cert, nil
+		}
+		c, err := X509KeyPair(certPEM, key)
+		if err != nil {
+			return nil, err
+		}
+		lastPEM = bytes.Clone(certPEM)
+		lastKey = key
+		cert = &c
+		return cert, nil
+	}
+}
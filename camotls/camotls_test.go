@@ -0,0 +1,90 @@
+package camotls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rbranson/camo"
+)
+
+func generateKeyPair(t *testing.T, cn string) ([]byte, camo.Secret[[]byte]) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, camo.Obscure(keyPEM)
+}
+
+func TestX509KeyPair(t *testing.T) {
+	certPEM, key := generateKeyPair(t, "a")
+	cert, err := X509KeyPair(certPEM, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cert.Leaf == nil || cert.Leaf.Subject.CommonName != "a" {
+		t.Errorf("unexpected certificate: %+v", cert.Leaf)
+	}
+}
+
+func TestX509KeyPairMismatch(t *testing.T) {
+	certPEM, _ := generateKeyPair(t, "a")
+	_, key := generateKeyPair(t, "b")
+	if _, err := X509KeyPair(certPEM, key); err == nil {
+		t.Errorf("expected error for mismatched key pair")
+	}
+}
+
+func TestGetCertificate(t *testing.T) {
+	certA, keyA := generateKeyPair(t, "a")
+	certB, keyB := generateKeyPair(t, "b")
+
+	certPEM, key := certA, keyA
+	get := GetCertificate(func() ([]byte, camo.Secret[[]byte], error) {
+		return certPEM, key, nil
+	})
+
+	first, err := get(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := get(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("expected unchanged key pair to be cached")
+	}
+
+	certPEM, key = certB, keyB
+	third, err := get(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if third.Leaf.Subject.CommonName != "b" {
+		t.Errorf("expected reloaded key pair, got %q", third.Leaf.Subject.CommonName)
+	}
+}
@@ -0,0 +1,181 @@
+// Package camovault provides a camo.Provider that reads secrets from the
+// HashiCorp Vault KV version 2 secrets engine. The Vault token is held in a
+// camo Secret, and is renewed in the background for as long as the Provider
+// is open.
+package camovault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/rbranson/camo"
+)
+
+// Config configures a Provider.
+type Config struct {
+	// Vault configures the Vault client. If nil, api.DefaultConfig is used,
+	// which reads the VAULT_* environment variables.
+	Vault *api.Config
+
+	// Token is the Vault token to authenticate with.
+	Token camo.Secret[string]
+
+	// Mount is the path the KV version 2 secrets engine is mounted at. It
+	// defaults to "secret".
+	Mount string
+
+	// Field is the field of a secret that is returned when the name doesn't
+	// specify one. It defaults to "value".
+	Field string
+
+	// OnRenewError is called when renewing the token fails. Renewal is
+	// retried after a short delay.
+	OnRenewError func(error)
+}
+
+// Provider is a camo.Provider that reads secrets from Vault. Names take the
+// form "path#field", such as "db/prod#password", where "#field" may be
+// omitted to use the default field.
+type Provider struct {
+	client       *api.Client
+	mount        string
+	field        string
+	onRenewError func(error)
+
+	mu    sync.Mutex
+	token camo.Secret[string]
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns a Provider that reads secrets from Vault. If the token is
+// renewable, it is renewed in the background until Close is called.
+func New(ctx context.Context, cfg Config) (*Provider, error) {
+	if !cfg.Token.Valid() {
+		return nil, errors.New("camovault: no token")
+	}
+	vaultCfg := cfg.Vault
+	if vaultCfg == nil {
+		vaultCfg = api.DefaultConfig()
+	}
+	client, err := api.NewClient(vaultCfg)
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(cfg.Token.Reveal())
+	p := &Provider{
+		client:       client,
+		mount:        orDefault(cfg.Mount, "secret"),
+		field:        orDefault(cfg.Field, "value"),
+		onRenewError: cfg.OnRenewError,
+		token:        cfg.Token,
+		done:         make(chan struct{}),
+	}
+
+	self, err := client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("camovault: looking up token: %w", err)
+	}
+	renewable, _ := self.TokenIsRenewable()
+	ttl, _ := self.TokenTTL()
+	renewCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	if renewable && ttl > 0 {
+		go p.renew(renewCtx, ttl)
+	} else {
+		close(p.done)
+	}
+	return p, nil
+}
+
+func orDefault(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// Token returns the current Vault token.
+func (p *Provider) Token() camo.Secret[string] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.token
+}
+
+// Get reads the secret with the given name from Vault.
+func (p *Provider) Get(ctx context.Context, name string) (camo.Secret[string], error) {
+	path, field, ok := strings.Cut(name, "#")
+	if !ok {
+		field = p.field
+	}
+	kv, err := p.client.KVv2(p.mount).Get(ctx, path)
+	if errors.Is(err, api.ErrSecretNotFound) {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	if err != nil {
+		return camo.Secret[string]{}, err
+	}
+	value, ok := kv.Data[field]
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("%w: %q", camo.ErrNotFound, name)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return camo.Secret[string]{}, fmt.Errorf("camovault: field %q of %q is a %T, not a string", field, path, value)
+	}
+	return camo.Obscure(str), nil
+}
+
+// Close stops renewing the token.
+func (p *Provider) Close() error {
+	p.cancel()
+	<-p.done
+	return nil
+}
+
+// renew renews the token when half of its TTL has passed.
+func (p *Provider) renew(ctx context.Context, ttl time.Duration) {
+	defer close(p.done)
+	for {
+		t := time.NewTimer(ttl / 2)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		resp, err := p.client.Auth().Token().RenewSelfWithContext(ctx, 0)
+		if err == nil && resp.Auth == nil {
+			err = errors.New("camovault: renewal returned no auth data")
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if p.onRenewError != nil {
+				p.onRenewError(err)
+			}
+			ttl = min(ttl, 10*time.Second)
+			continue
+		}
+		if resp.Auth.ClientToken != "" {
+			p.mu.Lock()
+			p.token = camo.Obscure(resp.Auth.ClientToken)
+			p.mu.Unlock()
+			p.client.SetToken(resp.Auth.ClientToken)
+		}
+		if !resp.Auth.Renewable {
+			return
+		}
+		ttl = time.Duration(resp.Auth.LeaseDuration) * time.Second
+		if ttl <= 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package camovault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/rbranson/camo"
+)
+
+type fakeVault struct {
+	ttl     int
+	renewed atomic.Int32
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "root-token" {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+	var resp any
+	switch r.URL.Path {
+	case "/v1/auth/token/lookup-self":
+		resp = map[string]any{"data": map[string]any{"ttl": f.ttl, "renewable": f.ttl > 0}}
+	case "/v1/auth/token/renew-self":
+		f.renewed.Add(1)
+		resp = map[string]any{"auth": map[string]any{"client_token": "root-token", "lease_duration": f.ttl, "renewable": true}}
+	case "/v1/secret/data/db":
+		resp = map[string]any{"data": map[string]any{
+			"data":     map[string]any{"value": "hunter2", "user": "admin", "port": 5432},
+			"metadata": map[string]any{"version": 1},
+		}}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[]}`))
+		return
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newProvider(t *testing.T, f *fakeVault) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	cfg := api.DefaultConfig()
+	cfg.Address = srv.URL
+	p, err := New(context.Background(), Config{Vault: cfg, Token: camo.Obscure("root-token")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func TestGet(t *testing.T) {
+	p := newProvider(t, &fakeVault{})
+	ctx := context.Background()
+
+	s, err := p.Get(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	s, err = p.Get(ctx, "db#user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "admin" {
+		t.Errorf("Reveal() = %q; want %q", got, "admin")
+	}
+
+	for _, name := range []string{"missing", "db#missing"} {
+		if _, err := p.Get(ctx, name); !errors.Is(err, camo.ErrNotFound) {
+			t.Errorf("Get(%q) err = %v; want camo.ErrNotFound", name, err)
+		}
+	}
+	if _, err := p.Get(ctx, "db#port"); err == nil || errors.Is(err, camo.ErrNotFound) {
+		t.Errorf("err = %v; want an error for a non-string field", err)
+	}
+}
+
+func TestRenew(t *testing.T) {
+	f := &fakeVault{ttl: 1}
+	p := newProvider(t, f)
+	deadline := time.Now().Add(5 * time.Second)
+	for f.renewed.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("token was not renewed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if p.Token() != camo.Obscure("root-token") {
+		t.Errorf("unexpected token after renewal")
+	}
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	if _, err := New(context.Background(), Config{}); err == nil {
+		t.Errorf("expected an error without a token")
+	}
+}
+
+var _ camo.Provider = (*Provider)(nil)
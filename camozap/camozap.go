@@ -0,0 +1,74 @@
+// Package camozap integrates camo with the zap logging library.
+package camozap
+
+import (
+	"fmt"
+
+	"github.com/rbranson/camo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Secret constructs a field that renders s as camo.Redacted.
+func Secret[O camo.Obscurable](key string, s camo.Secret[O]) zap.Field {
+	return zap.String(key, camo.Redacted)
+}
+
+// NewCore returns a zapcore.Core that scrubs the content of registered
+// secrets (see camo.Register) from the message and fields of every entry
+// before passing it on to c.
+//
+// String, byte string, error, Stringer and reflected fields are checked for
+// registered content. Fields that need to be scrubbed are replaced with a
+// string field holding their scrubbed form.
+func NewCore(c zapcore.Core) zapcore.Core {
+	return &scrubCore{Core: c}
+}
+
+type scrubCore struct {
+	zapcore.Core
+}
+
+func (c *scrubCore) With(fields []zapcore.Field) zapcore.Core {
+	return &scrubCore{Core: c.Core.With(scrubFields(fields))}
+}
+
+func (c *scrubCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *scrubCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = camo.Scrub(ent.Message)
+	return c.Core.Write(ent, scrubFields(fields))
+}
+
+func scrubFields(fields []zapcore.Field) []zapcore.Field {
+	scrubbed := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		scrubbed[i] = scrubField(f)
+	}
+	return scrubbed
+}
+
+func scrubField(f zapcore.Field) zapcore.Field {
+	var str string
+	switch f.Type {
+	case zapcore.StringType:
+		str = f.String
+	case zapcore.ByteStringType:
+		str = string(f.Interface.([]byte))
+	case zapcore.ErrorType, zapcore.StringerType:
+		str = fmt.Sprint(f.Interface)
+	case zapcore.ReflectType:
+		str = fmt.Sprintf("%+v", f.Interface)
+	default:
+		return f
+	}
+	if scrubbed := camo.Scrub(str); scrubbed != str {
+		return zap.String(f.Key, scrubbed)
+	}
+	return f
+}
@@ -0,0 +1,61 @@
+package camozap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSecret(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("login", Secret("password", camo.Obscure("hunter2")))
+
+	got := logs.All()[0].ContextMap()["password"]
+	if got != camo.Redacted {
+		t.Errorf("got = %v; want %q", got, camo.Redacted)
+	}
+}
+
+func TestNewCore(t *testing.T) {
+	s := camo.Obscure("hunter2")
+	camo.Register(s)
+	t.Cleanup(func() { camo.Unregister(s) })
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewCore(core)).With(zap.String("dsn", "postgres://bob:hunter2@db"))
+	logger.Info(
+		"logging in with hunter2",
+		zap.String("password", s.Reveal()),
+		zap.ByteString("raw", []byte("hunter2")),
+		zap.Error(errors.New("bad password hunter2")),
+		zap.Any("map", map[string]string{"k": "hunter2"}),
+		zap.Int("count", 2),
+	)
+	logger.Debug("filtered hunter2")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries; want 1", len(entries))
+	}
+	if got, want := entries[0].Message, "logging in with ***"; got != want {
+		t.Errorf("message = %q; want %q", got, want)
+	}
+	want := map[string]any{
+		"dsn":      "postgres://bob:***@db",
+		"password": "***",
+		"raw":      "***",
+		"error":    "bad password ***",
+		"map":      "map[k:***]",
+		"count":    int64(2),
+	}
+	got := entries[0].ContextMap()
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %#v; want %#v", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package camozerolog integrates camo with the zerolog logging library.
+package camozerolog
+
+import (
+	"io"
+
+	"github.com/rbranson/camo"
+	"github.com/rs/zerolog"
+)
+
+// Secret adds the field key to e with the value camo.Redacted, and returns e
+// for chaining.
+func Secret[O camo.Obscurable](e *zerolog.Event, key string, s camo.Secret[O]) *zerolog.Event {
+	return e.Str(key, camo.Redacted)
+}
+
+// NewWriter returns a writer for use as the output of a zerolog.Logger that
+// scrubs the content of registered secrets (see camo.Register) from every
+// event before writing it to w.
+//
+// zerolog emits each event with a single call to Write, so occurrences are
+// never split across writes. Content is matched against the encoded output,
+// so a secret containing characters that zerolog escapes will not be found.
+func NewWriter(w io.Writer) io.Writer {
+	return &scrubWriter{w: w}
+}
+
+type scrubWriter struct {
+	w io.Writer
+}
+
+func (w *scrubWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, camo.Scrub(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
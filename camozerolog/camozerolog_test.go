@@ -0,0 +1,51 @@
+package camozerolog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rbranson/camo"
+	"github.com/rs/zerolog"
+)
+
+func TestSecret(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	Secret(logger.Info(), "password", camo.Obscure("hunter2")).Msg("login")
+
+	want := `{"level":"info","password":"REDACTED","message":"login"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
+
+func TestSecretFieldsAreRedactedByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	s := camo.Obscure("hunter2")
+	logger.Info().Interface("a", s).Stringer("b", s).Msg("login")
+
+	want := `{"level":"info","a":"REDACTED","b":"camo.Secret[string](REDACTED)","message":"login"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	s := camo.Obscure("hunter2")
+	camo.Register(s)
+	t.Cleanup(func() { camo.Unregister(s) })
+
+	var buf bytes.Buffer
+	logger := zerolog.New(NewWriter(&buf))
+	logger.Info().
+		Str("password", s.Reveal()).
+		Err(errors.New("bad password hunter2")).
+		Msg("logging in with hunter2")
+
+	want := `{"level":"info","password":"***","error":"bad password ***","message":"logging in with ***"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package camo
+
+// CanaryEvent describes an access to the content of a canary secret.
+type CanaryEvent struct {
+	RevealInfo
+
+	// Stack is the stack trace of the goroutine that accessed the content,
+	// formatted like runtime/debug.Stack.
+	Stack []byte
+}
+
+// Canary returns a Secret that behaves like one returned by Obscure, except
+// that onAccess is called whenever its content is extracted, such as with
+// Reveal or AppendTo. Planting canary credentials that are never meant to be
+// used, and alerting when they are, is a way of detecting intrusions.
+//
+// The onAccess function is called synchronously by the goroutine extracting
+// the content, before the content is returned.
+func Canary[O Obscurable](content O, onAccess func(CanaryEvent), opts ...Option) Secret[O] {
+	s := Obscure(content, opts...)
+	// The box hasn't been shared yet, so it is safe to modify.
+	s.box().canary = onAccess
+	return s
+}
+
+// IsCanary reports if the Secret was created by Canary.
+func (s Secret[O]) IsCanary() bool {
+	return s.Valid() && s.box().canary != nil
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const camoPath = "github.com/rbranson/camo"
+
+// field is a field of the struct type.
+type field struct {
+	name   string
+	typ    string
+	tag    string
+	secret bool
+}
+
+// generate returns the source of a file declaring secureName for the struct
+// type named typeName in the package in dir. The file named output is
+// ignored, as it is the one being generated.
+func generate(dir, typeName, secureName, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgName, file, st, err := findStruct(fset, dir, typeName, output)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := structFields(fset, st)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", typeName, err)
+	}
+	if !slices.ContainsFunc(fields, func(f field) bool { return f.secret }) {
+		return nil, fmt.Errorf("%s has no fields tagged `camo:\"secret\"`", typeName)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by camogen -type %s; DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&b, "package %s\n\n", pkgName)
+	std, other := usedImports(file, st)
+	b.WriteString("import (\n\t\"fmt\"\n")
+	for _, imp := range std {
+		fmt.Fprintf(&b, "\t%s\n", imp)
+	}
+	fmt.Fprintf(&b, "\n\t%q\n", camoPath)
+	for _, imp := range other {
+		fmt.Fprintf(&b, "\t%s\n", imp)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// %s is %s with its secret fields held in camo Secrets.\n", secureName, typeName)
+	fmt.Fprintf(&b, "type %s struct {\n", secureName)
+	for _, f := range fields {
+		typ := f.typ
+		if f.secret {
+			typ = "camo.Secret[" + f.typ + "]"
+		}
+		fmt.Fprintf(&b, "\t%s %s", f.name, typ)
+		if f.tag != "" {
+			fmt.Fprintf(&b, " %s", quoteTag(f.tag))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "// Obscure returns c as a %s, obscuring its secret fields.\n", secureName)
+	fmt.Fprintf(&b, "func (c %s) Obscure() %s {\n", typeName, secureName)
+	fmt.Fprintf(&b, "\treturn %s{\n", secureName)
+	for _, f := range fields {
+		if f.secret {
+			fmt.Fprintf(&b, "\t\t%s: camo.Obscure(c.%[1]s),\n", f.name)
+		} else {
+			fmt.Fprintf(&b, "\t\t%s: c.%[1]s,\n", f.name)
+		}
+	}
+	b.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(&b, "// Reveal returns c as a %s, revealing its secret fields. Zero Secrets are\n// revealed as empty.\n", typeName)
+	fmt.Fprintf(&b, "func (c %s) Reveal() %s {\n", secureName, typeName)
+	fmt.Fprintf(&b, "\tv := %s{\n", typeName)
+	for _, f := range fields {
+		if !f.secret {
+			fmt.Fprintf(&b, "\t\t%s: c.%[1]s,\n", f.name)
+		}
+	}
+	b.WriteString("\t}\n")
+	for _, f := range fields {
+		if f.secret {
+			fmt.Fprintf(&b, "\tif c.%s.Valid() {\n\t\tv.%[1]s = c.%[1]s.Reveal()\n\t}\n", f.name)
+		}
+	}
+	b.WriteString("\treturn v\n}\n\n")
+
+	fmt.Fprintf(&b, "// String returns a representation of c with its secret fields redacted.\n")
+	fmt.Fprintf(&b, "func (c %s) String() string {\n", secureName)
+	var verbs, args []string
+	for _, f := range fields {
+		verb := "%#v"
+		if f.secret {
+			verb = "%v"
+		}
+		verbs = append(verbs, f.name+": "+verb)
+		args = append(args, "c."+f.name)
+	}
+	fmt.Fprintf(&b, "\treturn fmt.Sprintf(%q, %s)\n}\n", secureName+"{"+strings.Join(verbs, ", ")+"}", strings.Join(args, ", "))
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// findStruct parses the Go files in dir, except for output and tests, and
+// returns the declaration of the named struct type, along with the file it is
+// declared in and the name of the package.
+func findStruct(fset *token.FileSet, dir, typeName, output string) (string, *ast.File, *ast.StructType, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if base == output || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != typeName {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.TypeParams != nil {
+					return "", nil, nil, fmt.Errorf("%s is not a non-generic struct type", typeName)
+				}
+				return file.Name.Name, file, st, nil
+			}
+		}
+	}
+	return "", nil, nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+// structFields returns the fields of st.
+func structFields(fset *token.FileSet, st *ast.StructType) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded fields are not supported", fset.Position(f.Pos()))
+		}
+		var tag string
+		if f.Tag != nil {
+			var err error
+			if tag, err = strconv.Unquote(f.Tag.Value); err != nil {
+				return nil, err
+			}
+		}
+		secret := reflect.StructTag(tag).Get("camo") == "secret"
+		typ := types.ExprString(f.Type)
+		if secret && typ != "string" && typ != "[]byte" {
+			return nil, fmt.Errorf("%s: secret field has type %s, not string or []byte", fset.Position(f.Pos()), typ)
+		}
+		for _, name := range f.Names {
+			fields = append(fields, field{name: name.Name, typ: typ, tag: tag, secret: secret})
+		}
+	}
+	return fields, nil
+}
+
+// quoteTag returns tag as a Go string literal, preferring a raw one.
+func quoteTag(tag string) string {
+	if strconv.CanBackquote(tag) {
+		return "`" + tag + "`"
+	}
+	return strconv.Quote(tag)
+}
+
+// usedImports returns the import specs of file that are used by the field
+// types of st, formatted for an import block, split into those of the
+// standard library and the others.
+func usedImports(file *ast.File, st *ast.StructType) (std, other []string) {
+	used := make(map[string]bool)
+	ast.Inspect(st, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if path == camoPath || path == "fmt" {
+			continue
+		}
+		name := path[strings.LastIndexByte(path, '/')+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if used[name] {
+			spec := imp.Path.Value
+			if imp.Name != nil {
+				spec = imp.Name.Name + " " + spec
+			}
+			if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+				other = append(other, spec)
+			} else {
+				std = append(std, spec)
+			}
+		}
+	}
+	return std, other
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const configSrc = `package config
+
+import (
+	"net/url"
+	"time"
+)
+
+type Config struct {
+	Host     string        ` + "`json:\"host\"`" + `
+	Timeout  time.Duration
+	Endpoint *url.URL
+	Password string ` + "`json:\"password\" camo:\"secret\"`" + `
+	Key, IV  []byte ` + "`camo:\"secret\"`" + `
+}
+`
+
+const configTest = `package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGenerated(t *testing.T) {
+	c := Config{Host: "db", Password: "hunter2", Key: []byte("k")}
+	s := c.Obscure()
+	if got := s.Password.Reveal(); got != "hunter2" {
+		t.Errorf("Password = %q", got)
+	}
+	if str := s.String(); strings.Contains(str, "hunter2") || !strings.Contains(str, "db") {
+		t.Errorf("String() = %s", str)
+	}
+	r := s.Reveal()
+	if r.Host != "db" || r.Password != "hunter2" || !bytes.Equal(r.Key, []byte("k")) || len(r.IV) != 0 {
+		t.Errorf("Reveal() = %+v", r)
+	}
+	if (SecureConfig{}).Reveal().Password != "" {
+		t.Errorf("expected zero Secrets to be revealed as empty")
+	}
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.go"), []byte(configSrc), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(dir, "Config", "SecureConfig", "config_camo.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// Code generated by camogen -type Config; DO NOT EDIT.",
+		"\"time\"",
+		"\"net/url\"",
+		"Password camo.Secret[string] `json:\"password\" camo:\"secret\"`",
+		"Key      camo.Secret[[]byte] `camo:\"secret\"`",
+		"Timeout  time.Duration",
+		"func (c Config) Obscure() SecureConfig {",
+		"func (c SecureConfig) Reveal() Config {",
+		"func (c SecureConfig) String() string {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code doesn't contain %q:\n%s", want, src)
+		}
+	}
+	if t.Failed() {
+		return
+	}
+
+	// Check that the generated code compiles and works, if the go command
+	// is available.
+	if testing.Short() {
+		t.Skip("skipping build of generated code in short mode")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"config_camo.go": string(src),
+		"config_test.go": configTest,
+		"go.mod":         "module example.com/config\n\ngo 1.24\n\nrequire github.com/rbranson/camo v0.0.0\n\nreplace github.com/rbranson/camo => " + root + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goCmd, "test", "-mod=mod", "./...")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("testing generated code: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"no secrets":   "package p\n\ntype Config struct{ Host string }\n",
+		"bad type":     "package p\n\ntype Config struct{ Port int `camo:\"secret\"` }\n",
+		"not a struct": "package p\n\ntype Config int\n",
+		"missing":      "package p\n\ntype Other struct{}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(src), 0o666); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := generate(dir, "Config", "SecureConfig", "config_camo.go"); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
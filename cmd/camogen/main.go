@@ -0,0 +1,75 @@
+// Command camogen generates a parallel struct type for a config struct that
+// holds the fields tagged `camo:"secret"` in camo Secrets, along with methods
+// to convert between the two and a String method that redacts the secrets.
+// This makes it possible to retrofit large config types without writing the
+// boilerplate by hand.
+//
+// It is meant to be run with go generate:
+//
+//	//go:generate go run github.com/rbranson/camo/cmd/camogen -type Config
+//	type Config struct {
+//		Host     string
+//		Password string `camo:"secret"`
+//	}
+//
+// which generates, in config_camo.go:
+//
+//	type SecureConfig struct {
+//		Host     string
+//		Password camo.Secret[string] `camo:"secret"`
+//	}
+//
+//	func (c Config) Obscure() SecureConfig
+//	func (c SecureConfig) Reveal() Config
+//	func (c SecureConfig) String() string
+//
+// Tagged fields must have type string or []byte.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("camogen: ")
+
+	typeName := flag.String("type", "", "name of the struct type to generate for (required)")
+	secureName := flag.String("name", "", "name of the generated type (default Secure<type>)")
+	output := flag.String("output", "", "output file name (default <type>_camo.go)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: camogen -type T [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeName == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if *secureName == "" {
+		*secureName = "Secure" + *typeName
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_camo.go"
+	}
+	if !filepath.IsAbs(*output) {
+		*output = filepath.Join(dir, *output)
+	}
+
+	src, err := generate(dir, *typeName, *secureName, filepath.Base(*output))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o666); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,20 @@
+package camo
+
+import (
+	"crypto/subtle"
+	"runtime"
+)
+
+// ContentEqual reports if a and b have the same content, regardless of their
+// types, such as for a credential from a config as a Secret[string] and from
+// a file as a Secret[[]byte], without revealing either. The content is
+// compared in constant time, although its length is not hidden. Two zero
+// Secrets are equal, and a zero Secret is not equal to any other.
+func ContentEqual[A, B Obscurable](a Secret[A], b Secret[B]) bool {
+	if !a.Valid() || !b.Valid() {
+		return a.Valid() == b.Valid()
+	}
+	defer runtime.KeepAlive(a)
+	defer runtime.KeepAlive(b)
+	return subtle.ConstantTimeCompare(a.view(), b.view()) == 1
+}
@@ -0,0 +1,24 @@
+package camo
+
+import "testing"
+
+func TestContentEqual(t *testing.T) {
+	if !ContentEqual(Obscure("hunter2"), Obscure([]byte("hunter2"))) {
+		t.Errorf("expected a string and bytes with the same content to be equal")
+	}
+	if !ContentEqual(Obscure("hunter2"), Obscure("hunter2", Guarded())) {
+		t.Errorf("expected a guarded secret to equal one with the same content")
+	}
+	if ContentEqual(Obscure("hunter2"), Obscure([]byte("hunter3"))) {
+		t.Errorf("expected different content to be unequal")
+	}
+	if ContentEqual(Obscure("hunter2"), Obscure([]byte("hunter"))) {
+		t.Errorf("expected content of different lengths to be unequal")
+	}
+	if !ContentEqual(Secret[string]{}, Secret[[]byte]{}) {
+		t.Errorf("expected zero Secrets to be equal")
+	}
+	if ContentEqual(Obscure(""), Secret[[]byte]{}) || ContentEqual(Secret[string]{}, Obscure([]byte{})) {
+		t.Errorf("expected a zero Secret to be unequal to an empty one")
+	}
+}
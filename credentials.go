@@ -0,0 +1,78 @@
+package camo
+
+import (
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+)
+
+// Credentials pairs an identity, such as a username or a client ID, with the
+// password or token that authenticates it, since the two almost always travel
+// together. Its string and log representations include the username and
+// redact the password.
+type Credentials struct {
+	Username string
+	Password Secret[string]
+}
+
+// BasicAuth returns the value of an Authorization header for HTTP basic
+// authentication with c, as described in RFC 7617. The intermediate
+// plaintext is wiped. It panics if the password is zero.
+func (c Credentials) BasicAuth() string {
+	if !c.Password.Valid() {
+		panicZero("BasicAuth")
+	}
+	defer runtime.KeepAlive(c.Password)
+	c.Password.revealed("BasicAuth")
+	buf := make([]byte, 0, len(c.Username)+1+len(c.Password.str()))
+	buf = append(buf, c.Username...)
+	buf = append(buf, ':')
+	buf = append(buf, c.Password.str()...)
+	defer wipe(buf)
+	return "Basic " + base64.StdEncoding.EncodeToString(buf)
+}
+
+// SetBasicAuth sets the Authorization header of r to use HTTP basic
+// authentication with c. It panics if the password is zero.
+func (c Credentials) SetBasicAuth(r *http.Request) {
+	r.Header.Set("Authorization", c.BasicAuth())
+}
+
+// Userinfo returns c as URL userinfo, with the revealed password. If the
+// password is zero, only the username is included.
+func (c Credentials) Userinfo() *url.Userinfo {
+	if !c.Password.Valid() {
+		return url.User(c.Username)
+	}
+	return url.UserPassword(c.Username, c.Password.Reveal())
+}
+
+// URL returns a URL that combines u with c, which can be logged freely and
+// resolved at the point of connecting. See NewURL.
+func (c Credentials) URL(u *url.URL) URL {
+	return NewURL(u, c.Username, c.Password)
+}
+
+// String implements fmt.Stringer, returning the username and a redacted
+// password, such as "alice:REDACTED".
+func (c Credentials) String() string {
+	return c.Username + ":" + Redacted
+}
+
+// GoString implements fmt.GoStringer, so that the %#v verb also redacts the
+// password.
+func (c Credentials) GoString() string {
+	return "camo.Credentials{Username:" + strconv.Quote(c.Username) + ", Password:" + Redacted + "}"
+}
+
+// LogValue implements slog.LogValuer, rendering c as a group of the username
+// and a redacted password.
+func (c Credentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", c.Username),
+		slog.String("password", Redacted),
+	)
+}
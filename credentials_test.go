@@ -0,0 +1,64 @@
+package camo
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCredentialsBasicAuth(t *testing.T) {
+	c := Credentials{Username: "alice", Password: Obscure("hunter2")}
+	if got, want := c.BasicAuth(), "Basic YWxpY2U6aHVudGVyMg=="; got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	c.SetBasicAuth(r)
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != "alice" || pass != "hunter2" {
+		t.Errorf("got = %q, %q, %v", user, pass, ok)
+	}
+
+	zero := Credentials{Username: "alice"}
+	if _, ok := capturePanic(func() { zero.BasicAuth() }); !ok {
+		t.Errorf("expected BasicAuth() with a zero password to panic")
+	}
+}
+
+func TestCredentialsURL(t *testing.T) {
+	c := Credentials{Username: "alice", Password: Obscure("hunter2")}
+	if got := c.Userinfo().String(); got != "alice:hunter2" {
+		t.Errorf("got = %q; want %q", got, "alice:hunter2")
+	}
+	if got := (Credentials{Username: "alice"}).Userinfo().String(); got != "alice" {
+		t.Errorf("got = %q; want %q", got, "alice")
+	}
+
+	u := c.URL(&url.URL{Scheme: "postgres", Host: "db"})
+	if got := u.String(); got != "postgres://alice:REDACTED@db" {
+		t.Errorf("got = %q", got)
+	}
+	if got := u.Resolve().String(); got != "postgres://alice:hunter2@db" {
+		t.Errorf("got = %q", got)
+	}
+}
+
+func TestCredentialsRedacts(t *testing.T) {
+	c := Credentials{Username: "alice", Password: Obscure("hunter2")}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		got := fmt.Sprintf(format, c)
+		if strings.Contains(got, "hunter2") || !strings.Contains(got, "alice") {
+			t.Errorf("Sprintf(%q) = %q", format, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("connecting", "creds", c)
+	if got := buf.String(); strings.Contains(got, "hunter2") || !strings.Contains(got, "creds.username=alice creds.password=REDACTED") {
+		t.Errorf("got = %q", got)
+	}
+}
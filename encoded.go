@@ -0,0 +1,68 @@
+package camo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+var errInvalidHex = errors.New("camo: invalid hex: invalid byte")
+
+// ObscureBase64 returns a Secret holding the key material or other content
+// encoded in s with enc, such as base64.StdEncoding, decoding it directly
+// into memory owned by the Secret. The error doesn't include any of s.
+func ObscureBase64(s string, enc *base64.Encoding, opts ...Option) (Secret[[]byte], error) {
+	buf := make([]byte, enc.DecodedLen(len(s)))
+	n, err := enc.Decode(buf, unsafe.Slice(unsafe.StringData(s), len(s)))
+	if err != nil {
+		wipe(buf)
+		return Secret[[]byte]{}, fmt.Errorf("camo: invalid base64: %w", err)
+	}
+	return obscureOwned[[]byte](buf[:n], opts...), nil
+}
+
+// ObscureHex returns a Secret holding the key material or other content
+// encoded in s as hexadecimal, decoding it directly into memory owned by the
+// Secret. The error doesn't include any of s.
+func ObscureHex(s string, opts ...Option) (Secret[[]byte], error) {
+	buf := make([]byte, hex.DecodedLen(len(s)))
+	n, err := hex.Decode(buf, unsafe.Slice(unsafe.StringData(s), len(s)))
+	if err != nil {
+		wipe(buf)
+		var invalid hex.InvalidByteError
+		if errors.As(err, &invalid) {
+			// The error includes the invalid byte.
+			return Secret[[]byte]{}, errInvalidHex
+		}
+		return Secret[[]byte]{}, fmt.Errorf("camo: invalid hex: %w", err)
+	}
+	return obscureOwned[[]byte](buf[:n], opts...), nil
+}
+
+// RevealBase64 returns the secret encoded with enc, such as
+// base64.StdEncoding, without a plaintext copy of it being made first. It
+// panics if the secret is zero.
+func (s Secret[O]) RevealBase64(enc *base64.Encoding) string {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("RevealBase64")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("RevealBase64")
+	return enc.EncodeToString(s.view())
+}
+
+// RevealHex returns the secret encoded as lowercase hexadecimal, without a
+// plaintext copy of it being made first. It panics if the secret is zero.
+func (s Secret[O]) RevealHex() string {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("RevealHex")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("RevealHex")
+	return hex.EncodeToString(s.view())
+}
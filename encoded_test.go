@@ -0,0 +1,55 @@
+package camo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestObscureBase64(t *testing.T) {
+	s, err := ObscureBase64("aHVudGVyMg==", base64.StdEncoding)
+	if err != nil || string(s.Reveal()) != "hunter2" {
+		t.Errorf("got = %q, %v; want %q", s.Reveal(), err, "hunter2")
+	}
+	s, err = ObscureBase64("aHVudGVyMg", base64.RawURLEncoding, Labeled("key"))
+	if err != nil || string(s.Reveal()) != "hunter2" || s.Label() != "key" {
+		t.Errorf("got = %q, %v; want %q", s.Reveal(), err, "hunter2")
+	}
+	if _, err := ObscureBase64("aHVu!GVyMg==", base64.StdEncoding); err == nil {
+		t.Errorf("expected an error for invalid base64")
+	}
+}
+
+func TestObscureHex(t *testing.T) {
+	s, err := ObscureHex("68756e74657232")
+	if err != nil || string(s.Reveal()) != "hunter2" {
+		t.Errorf("got = %q, %v; want %q", s.Reveal(), err, "hunter2")
+	}
+	if _, err := ObscureHex("68756e7465723"); !errors.Is(err, hex.ErrLength) {
+		t.Errorf("got err = %v; want %v", err, hex.ErrLength)
+	}
+	_, err = ObscureHex("68756e74657z32")
+	if err == nil || strings.Contains(err.Error(), "z") {
+		t.Errorf("got err = %v; want an error without the invalid byte", err)
+	}
+}
+
+func TestRevealEncoded(t *testing.T) {
+	s := Obscure("hunter2")
+	if got := s.RevealBase64(base64.StdEncoding); got != "aHVudGVyMg==" {
+		t.Errorf("got = %q; want %q", got, "aHVudGVyMg==")
+	}
+	if got := s.RevealHex(); got != "68756e74657232" {
+		t.Errorf("got = %q; want %q", got, "68756e74657232")
+	}
+
+	var zero Secret[[]byte]
+	if _, ok := capturePanic(func() { zero.RevealBase64(base64.StdEncoding) }); !ok {
+		t.Errorf("expected zero.RevealBase64() to panic")
+	}
+	if _, ok := capturePanic(func() { zero.RevealHex() }); !ok {
+		t.Errorf("expected zero.RevealHex() to panic")
+	}
+}
@@ -0,0 +1,109 @@
+package camo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"sync/atomic"
+)
+
+// Redacted is the placeholder emitted in place of the content of a Secret
+// whenever it is encoded or formatted.
+const Redacted = "REDACTED"
+
+// ErrGob is returned when a Secret is gob-encoded under the GobRefuse policy,
+// or when gob data other than the redacted sentinel is decoded into a Secret.
+var ErrGob = errors.New("camo: Secret cannot be transported with gob")
+
+// ErrRedacted is returned when Redacted is decoded into a Secret, such as
+// when encoded data that was produced by marshaling a Secret is decoded
+// again, so that the placeholder isn't silently taken for the content.
+var ErrRedacted = errors.New("camo: cannot decode the redacted placeholder as a Secret")
+
+// GobPolicy controls what happens when a Secret is gob-encoded.
+type GobPolicy int32
+
+const (
+	// GobRefuse causes gob-encoding a Secret to fail with ErrGob. This is
+	// the default, so that secrets crossing an RPC boundary fail loudly
+	// instead of silently arriving empty.
+	GobRefuse GobPolicy = iota
+
+	// GobRedact causes a Secret to be gob-encoded as a redacted sentinel,
+	// which decodes as a zero Secret on the receiving side.
+	GobRedact
+)
+
+var gobPolicy atomic.Int32
+
+// SetGobPolicy sets the process-wide GobPolicy.
+func SetGobPolicy(p GobPolicy) {
+	gobPolicy.Store(int32(p))
+}
+
+// MarshalText implements encoding.TextMarshaler. It always returns Redacted,
+// so encoders that respect the interface (encoding/json, BurntSushi/toml,
+// etc.) never emit the secret content. As a result, encoding and decoding a
+// Secret doesn't round-trip: decoding the output fails with ErrRedacted.
+func (s Secret[O]) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing s with a
+// Secret that obscures a copy of text. This allows configuration decoders to
+// populate Secret fields directly. It returns ErrRedacted if text is
+// Redacted, which is what MarshalText returns.
+func (s *Secret[O]) UnmarshalText(text []byte) error {
+	if string(text) == Redacted {
+		return ErrRedacted
+	}
+	*s = Obscure(O(text))
+	return nil
+}
+
+// Set replaces s with a Secret that obscures a copy of value. It implements
+// the Setter interface of envconfig and similar libraries, so that Secret
+// fields can be populated directly from environment variables.
+func (s *Secret[O]) Set(value string) error {
+	*s = convert[O](Obscure(value))
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, encoding the element with Redacted as
+// its character data.
+func (s Secret[O]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(Redacted, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing s with a Secret that
+// obscures the character data of the element. Like UnmarshalText, it returns
+// ErrRedacted if the character data is Redacted.
+func (s *Secret[O]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var content O
+	if err := d.DecodeElement(&content, &start); err != nil {
+		return err
+	}
+	if string(content) == Redacted {
+		return ErrRedacted
+	}
+	*s = Obscure(content)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder according to the current GobPolicy.
+func (s Secret[O]) GobEncode() ([]byte, error) {
+	if GobPolicy(gobPolicy.Load()) != GobRedact {
+		return nil, ErrGob
+	}
+	return []byte(Redacted), nil
+}
+
+// GobDecode implements gob.GobDecoder. The redacted sentinel decodes as a
+// zero Secret, and anything else fails with ErrGob.
+func (s *Secret[O]) GobDecode(data []byte) error {
+	if !bytes.Equal(data, []byte(Redacted)) {
+		return ErrGob
+	}
+	*s = Secret[O]{}
+	return nil
+}
@@ -0,0 +1,152 @@
+package camo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	got, err := Obscure("hunter2").MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != Redacted {
+		t.Errorf("got = %q; want %q", got, Redacted)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	in := []byte("hunter2")
+	var s Secret[[]byte]
+	if err := s.UnmarshalText(in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	in[0] = 'H'
+	if got := s.Reveal(); string(got) != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestSet(t *testing.T) {
+	var s Secret[string]
+	if err := s.Set("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if s != Obscure("hunter2") {
+		t.Errorf("expected Set to obscure the value")
+	}
+	var b Secret[[]byte]
+	if err := b.Set("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Reveal(); string(got) != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestTextRoundTripThroughJSON(t *testing.T) {
+	type config struct {
+		Password Secret[string] `json:"password"`
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"password":"hunter2"}`), &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Password.Reveal(); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"password":"REDACTED"}`; string(out) != want {
+		t.Errorf("got = %s; want %s", out, want)
+	}
+	if err := json.Unmarshal(out, &c); !errors.Is(err, ErrRedacted) {
+		t.Errorf("got err = %v; want %v", err, ErrRedacted)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	type config struct {
+		XMLName  xml.Name       `xml:"config"`
+		Password Secret[[]byte] `xml:"password"`
+		Token    Secret[string] `xml:"token,attr"`
+	}
+
+	var c config
+	in := `<config token="abc"><password>hunter2</password></config>`
+	if err := xml.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.Password.Reveal(); string(got) != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if got := c.Token.Reveal(); got != "abc" {
+		t.Errorf("got = %q; want %q", got, "abc")
+	}
+
+	out, err := xml.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<config token="REDACTED"><password>REDACTED</password></config>`
+	if string(out) != want {
+		t.Errorf("got = %s; want %s", out, want)
+	}
+	for _, in := range []string{want, `<config><password>REDACTED</password></config>`} {
+		if err := xml.Unmarshal([]byte(in), &c); !errors.Is(err, ErrRedacted) {
+			t.Errorf("got err = %v for %s; want %v", err, in, ErrRedacted)
+		}
+	}
+}
+
+func TestGobRefusesByDefault(t *testing.T) {
+	type payload struct {
+		Token Secret[string]
+	}
+
+	err := gob.NewEncoder(&bytes.Buffer{}).Encode(payload{Token: Obscure("abc")})
+	if !errors.Is(err, ErrGob) {
+		t.Errorf("got err = %v; want %v", err, ErrGob)
+	}
+}
+
+func TestGobRedactPolicy(t *testing.T) {
+	SetGobPolicy(GobRedact)
+	t.Cleanup(func() { SetGobPolicy(GobRefuse) })
+
+	type payload struct {
+		Name  string
+		Token Secret[string]
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(payload{Name: "x", Token: Obscure("abc")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("abc")) {
+		t.Errorf("gob output contains secret content")
+	}
+
+	var got payload
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Token.Valid() {
+		t.Errorf("expected decoded Secret to be zero")
+	}
+}
+
+func TestGobDecodeRejectsContent(t *testing.T) {
+	var s Secret[string]
+	if err := s.GobDecode([]byte("abc")); !errors.Is(err, ErrGob) {
+		t.Errorf("got err = %v; want %v", err, ErrGob)
+	}
+}
@@ -0,0 +1,19 @@
+package camo
+
+import "os"
+
+// FromEnv returns a Secret holding the value of the environment variable
+// named name, and unsets the variable so that the value no longer shows up in
+// os.Environ, the environment of child processes, or debug endpoints that dump
+// the environment. The boolean reports if the variable was set.
+//
+// The copy of the environment that the Go runtime keeps can't be wiped, so
+// this only limits further exposure of the value.
+func FromEnv(name string) (Secret[string], bool) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return Secret[string]{}, false
+	}
+	os.Unsetenv(name)
+	return Obscure(value), true
+}
@@ -0,0 +1,35 @@
+package camo
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("CAMO_TEST_SECRET", "hunter2")
+	s, ok := FromEnv("CAMO_TEST_SECRET")
+	if !ok {
+		t.Fatalf("expected variable to be found")
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if _, ok := os.LookupEnv("CAMO_TEST_SECRET"); ok {
+		t.Errorf("expected variable to be unset")
+	}
+}
+
+func TestFromEnvUnset(t *testing.T) {
+	s, ok := FromEnv("CAMO_TEST_SECRET_UNSET")
+	if ok || s.Valid() {
+		t.Errorf("FromEnv() = %v, %v; want a zero secret and false", s, ok)
+	}
+}
+
+func TestFromEnvEmpty(t *testing.T) {
+	t.Setenv("CAMO_TEST_SECRET", "")
+	s, ok := FromEnv("CAMO_TEST_SECRET")
+	if !ok || !s.Valid() || s.Reveal() != "" {
+		t.Errorf("FromEnv() = %v, %v; want a valid empty secret and true", s, ok)
+	}
+}
@@ -0,0 +1,84 @@
+package camo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrExpired is returned when using an ExpiringSecret that has expired and
+// can't be refreshed.
+var ErrExpired = errors.New("camo: secret expired")
+
+// ExpiringSecret is a Secret that is only usable until it expires, such as a
+// short-lived access token. This prevents a stale token from being silently
+// reused after it stops being accepted.
+//
+// It is safe for concurrent use.
+type ExpiringSecret[O Obscurable] struct {
+	refresh func() (Secret[O], time.Time, error)
+
+	mu        sync.Mutex
+	s         Secret[O]
+	expiresAt time.Time
+}
+
+// Expiring returns an ExpiringSecret holding s until expiresAt. If refresh is
+// not nil, it is called to get a new Secret and expiry time when the Secret
+// is used after it has expired.
+func Expiring[O Obscurable](s Secret[O], expiresAt time.Time, refresh func() (Secret[O], time.Time, error)) *ExpiringSecret[O] {
+	return &ExpiringSecret[O]{
+		refresh:   refresh,
+		s:         s,
+		expiresAt: expiresAt,
+	}
+}
+
+// Valid reports if the Secret is valid and hasn't expired. It doesn't
+// refresh the Secret.
+func (e *ExpiringSecret[O]) Valid() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.s.Valid() && time.Now().Before(e.expiresAt)
+}
+
+// ExpiresAt returns the time at which the current Secret expires.
+func (e *ExpiringSecret[O]) ExpiresAt() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.expiresAt
+}
+
+// Get returns the Secret if it hasn't expired. Otherwise, it refreshes the
+// Secret if there is a refresh function, or returns ErrExpired. A refreshed
+// Secret that has already expired is also reported as ErrExpired.
+func (e *ExpiringSecret[O]) Get() (Secret[O], error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if time.Now().Before(e.expiresAt) {
+		return e.s, nil
+	}
+	if e.refresh == nil {
+		return Secret[O]{}, ErrExpired
+	}
+	s, expiresAt, err := e.refresh()
+	if err != nil {
+		return Secret[O]{}, err
+	}
+	e.s, e.expiresAt = s, expiresAt
+	if !time.Now().Before(expiresAt) {
+		return Secret[O]{}, ErrExpired
+	}
+	return s, nil
+}
+
+// Reveal returns the underlying secret data like Secret.Reveal, refreshing
+// the Secret if it has expired like Get.
+func (e *ExpiringSecret[O]) Reveal() (O, error) {
+	s, err := e.Get()
+	if err != nil {
+		var zero O
+		return zero, err
+	}
+	return s.Reveal(), nil
+}
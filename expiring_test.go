@@ -0,0 +1,57 @@
+package camo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpiring(t *testing.T) {
+	e := Expiring(Obscure("token"), time.Now().Add(time.Hour), nil)
+	if !e.Valid() {
+		t.Errorf("expected the secret to be valid")
+	}
+	if got, err := e.Reveal(); err != nil || got != "token" {
+		t.Errorf("Reveal() = %q, %v; want %q", got, err, "token")
+	}
+
+	e = Expiring(Obscure("token"), time.Now().Add(-time.Second), nil)
+	if e.Valid() {
+		t.Errorf("expected an expired secret to be invalid")
+	}
+	if _, err := e.Reveal(); !errors.Is(err, ErrExpired) {
+		t.Errorf("err = %v; want ErrExpired", err)
+	}
+}
+
+func TestExpiringRefresh(t *testing.T) {
+	var calls int
+	refresh := func() (Secret[[]byte], time.Time, error) {
+		calls++
+		switch calls {
+		case 1:
+			return Secret[[]byte]{}, time.Time{}, errors.New("unavailable")
+		case 2:
+			return Obscure([]byte("v2")), time.Now().Add(time.Hour), nil
+		}
+		t.Fatalf("unexpected refresh")
+		panic("unreachable")
+	}
+	e := Expiring(Obscure([]byte("v1")), time.Now().Add(-time.Second), refresh)
+	if _, err := e.Get(); err == nil || errors.Is(err, ErrExpired) {
+		t.Errorf("err = %v; want the refresh error", err)
+	}
+	s, err := e.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != Obscure([]byte("v2")) {
+		t.Errorf("expected the refreshed secret")
+	}
+	if _, err := e.Get(); err != nil {
+		t.Errorf("expected the refreshed secret to be reused, got %v", err)
+	}
+	if !e.Valid() {
+		t.Errorf("expected the refreshed secret to be valid")
+	}
+}
@@ -0,0 +1,82 @@
+package camo
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInsecurePermissions is returned by FromFile when a secret file is
+// readable by its group or by others.
+var ErrInsecurePermissions = errors.New("camo: secret file is readable by group or others")
+
+// FromFile returns a Secret holding the content of the file at path, such as
+// a Docker or Kubernetes secret mount. The content is read into buffers that
+// are wiped once they are no longer needed, so the caller never touches it.
+//
+// Unless AllowInsecurePermissions is given, the file must not be readable by
+// its group or by others, and ErrInsecurePermissions is returned if it is.
+// This check is skipped on Windows. Pass Trimmed to strip the trailing
+// newline that such files often end with.
+func FromFile(path string, opts ...Option) (Secret[[]byte], error) {
+	o := makeOptions(opts)
+	f, err := os.Open(path)
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	if !o.allowInsecure && runtime.GOOS != "windows" && fi.Mode().Perm()&0o044 != 0 {
+		return Secret[[]byte]{}, &fs.PathError{Op: "open", Path: path, Err: ErrInsecurePermissions}
+	}
+	buf, err := readAll(f, fi.Size())
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	return obscureOwned[[]byte](buf, opts...), nil
+}
+
+// readAll reads r until EOF like io.ReadAll, but wipes every buffer that is
+// outgrown. The sizeHint is used as the initial capacity.
+func readAll(r io.Reader, sizeHint int64) ([]byte, error) {
+	buf := make([]byte, 0, max(sizeHint, 0)+1)
+	for {
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			wipe(buf)
+			return nil, err
+		}
+		if len(buf) == cap(buf) {
+			grown := make([]byte, len(buf), 2*cap(buf))
+			copy(grown, buf)
+			wipe(buf)
+			buf = grown
+		}
+	}
+}
+
+// trimTrailingSpace returns buf without its trailing white space, wiping the
+// bytes that are trimmed.
+func trimTrailingSpace(buf []byte) []byte {
+	n := len(buf)
+	for n > 0 {
+		r, size := utf8.DecodeLastRune(buf[:n])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		n -= size
+	}
+	wipe(buf[n:])
+	return buf[:n]
+}
@@ -0,0 +1,100 @@
+package camo
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeSecretFile(t *testing.T, content string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	// WriteFile is subject to the umask.
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFromFile(t *testing.T) {
+	path := writeSecretFile(t, "hunter2\n", 0o600)
+	s, err := FromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2\n")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2\n")
+	}
+
+	s, err = FromFile(path, Trimmed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestFromFileLarge(t *testing.T) {
+	content := strings.Repeat("0123456789", 10000)
+	s, err := FromFile(writeSecretFile(t, content, 0o600))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); string(got) != content {
+		t.Errorf("Reveal() returned %d bytes; want %d", len(got), len(content))
+	}
+}
+
+func TestFromFileInsecurePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions are not checked on Windows")
+	}
+	path := writeSecretFile(t, "hunter2", 0o644)
+	if _, err := FromFile(path); !errors.Is(err, ErrInsecurePermissions) {
+		t.Errorf("err = %v; want ErrInsecurePermissions", err)
+	}
+	s, err := FromFile(path, AllowInsecurePermissions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestFromFileNotExist(t *testing.T) {
+	_, err := FromFile(filepath.Join(t.TempDir(), "missing"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v; want os.ErrNotExist", err)
+	}
+}
+
+func TestTrimTrailingSpace(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"abc":        "abc",
+		"abc\n":      "abc",
+		"abc \t\r\n": "abc",
+		" abc \n":    " abc",
+		"\n\n":       "",
+		"a b\n":      "a b",
+	}
+	for in, want := range tests {
+		buf := []byte(in)
+		got := trimTrailingSpace(buf)
+		if string(got) != want {
+			t.Errorf("trimTrailingSpace(%q) = %q; want %q", in, got, want)
+		}
+		if tail := buf[len(got):]; !bytes.Equal(tail, make([]byte, len(tail))) {
+			t.Errorf("trimTrailingSpace(%q) left %q behind", in, tail)
+		}
+	}
+}
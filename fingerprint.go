@@ -0,0 +1,65 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// fingerprintSize is the number of bytes of the HMAC in a fingerprint.
+const fingerprintSize = 8
+
+var (
+	fingerprintKey atomic.Pointer[Secret[[]byte]]
+
+	processFingerprintKey = sync.OnceValue(func() Secret[[]byte] {
+		key, err := GenerateRandom(sha256.Size)
+		if err != nil {
+			panic(err)
+		}
+		return key
+	})
+)
+
+// SetFingerprintKey sets the process-wide key used by Fingerprint. By
+// default, a random key is generated for each process, so fingerprints can
+// only be correlated within a process. Setting the same key in every process
+// makes fingerprints stable across processes and restarts. The key must be
+// kept secret as well, since anyone who has it can check guesses of the
+// content of a secret against its fingerprint. Setting a zero key restores
+// the default.
+func SetFingerprintKey(key Secret[[]byte]) {
+	if !key.Valid() {
+		fingerprintKey.Store(nil)
+		return
+	}
+	fingerprintKey.Store(&key)
+}
+
+// Fingerprint returns a short identifier for the content of the secret, so
+// that logs and metrics can refer to a credential, such as to say that it
+// was rotated, without revealing anything about it. It is the first 8 bytes
+// of the HMAC-SHA256 of the content, keyed with the fingerprint key (see
+// SetFingerprintKey), in hex. Secrets with the same content have the same
+// fingerprint, regardless of their type. It returns "" if the secret is
+// zero.
+func (s Secret[O]) Fingerprint() string {
+	ss := s.secret()
+	if ss.p == nil {
+		return ""
+	}
+	key := processFingerprintKey()
+	if p := fingerprintKey.Load(); p != nil {
+		key = *p
+	}
+	defer runtime.KeepAlive(s)
+	// Like hashing with KeyedHash, fingerprinting isn't an extraction of the
+	// key, so it doesn't go through NewHMAC, which would report it.
+	defer runtime.KeepAlive(key)
+	mac := hmac.New(sha256.New, key.view())
+	mac.Write(s.view())
+	return hex.EncodeToString(mac.Sum(nil)[:fingerprintSize])
+}
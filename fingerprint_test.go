@@ -0,0 +1,59 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := Obscure("hunter2")
+	fp := a.Fingerprint()
+	if len(fp) != 2*fingerprintSize {
+		t.Errorf("got fingerprint %q", fp)
+	}
+	if got := Obscure("hunter2").Fingerprint(); got != fp {
+		t.Errorf("got %q for the same content, want %q", got, fp)
+	}
+	if got := Obscure([]byte("hunter2")).Fingerprint(); got != fp {
+		t.Errorf("got %q for the same content as bytes, want %q", got, fp)
+	}
+	if got := Obscure("hunter3").Fingerprint(); got == fp {
+		t.Errorf("got the same fingerprint for different content")
+	}
+	if got := (Secret[string]{}).Fingerprint(); got != "" {
+		t.Errorf("got %q for a zero secret", got)
+	}
+}
+
+func TestSetFingerprintKey(t *testing.T) {
+	s := Obscure("hunter2")
+	def := s.Fingerprint()
+
+	key := []byte("fingerprint key")
+	SetFingerprintKey(Obscure(key))
+	t.Cleanup(func() { SetFingerprintKey(Secret[[]byte]{}) })
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("hunter2"))
+	want := hex.EncodeToString(mac.Sum(nil)[:fingerprintSize])
+	if got := s.Fingerprint(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	SetFingerprintKey(Secret[[]byte]{})
+	if got := s.Fingerprint(); got != def {
+		t.Errorf("got %q after resetting the key, want %q", got, def)
+	}
+}
+
+func TestFingerprintIsNotAReveal(t *testing.T) {
+	key := Obscure([]byte("fingerprint key"))
+	SetFingerprintKey(key)
+	defer SetFingerprintKey(Secret[[]byte]{})
+	before := ReadStats().Reveals
+	Obscure("hunter2").Fingerprint()
+	if got := ReadStats().Reveals; got != before {
+		t.Errorf("Fingerprint counted %d reveals; want 0", got-before)
+	}
+}
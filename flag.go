@@ -0,0 +1,69 @@
+package camo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SecretFlag is a flag.Value holding a Secret, so that secrets can be
+// passed on the command line without being printed in usage output. Use it
+// with flag.Var:
+//
+//	var password camo.SecretFlag
+//	flag.Var(&password, "password", "database `password`, @file, or env:VAR")
+//
+// As command lines are visible to other users on most systems, the value is
+// usually better given indirectly, as described by ParseSecretFlag.
+type SecretFlag struct {
+	Secret Secret[string]
+}
+
+// Set implements flag.Value, setting the Secret to the result of
+// ParseSecretFlag.
+func (f *SecretFlag) Set(value string) error {
+	s, err := ParseSecretFlag(value)
+	if err != nil {
+		return err
+	}
+	f.Secret = s
+	return nil
+}
+
+// String implements flag.Value. It returns Redacted if the Secret is set, so
+// that a default value is printed as redacted in usage output.
+func (f *SecretFlag) String() string {
+	if f == nil || !f.Secret.Valid() {
+		return ""
+	}
+	return Redacted
+}
+
+// Get implements flag.Getter, returning the Secret.
+func (f *SecretFlag) Get() any {
+	return f.Secret
+}
+
+// ParseSecretFlag returns a Secret for the value of a flag, which is one of:
+//
+//   - "@" followed by a path, for the content of the file at the path, read
+//     with FromFile and Trimmed, so it must not be readable by other users;
+//   - "env:" followed by a name, for the value of the environment variable
+//     with the name, read with FromEnv, so it is unset once read;
+//   - anything else, for the value itself.
+func ParseSecretFlag(value string) (Secret[string], error) {
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		s, err := FromFile(path, Trimmed())
+		if err != nil {
+			return Secret[string]{}, err
+		}
+		return convert[string](s), nil
+	}
+	if name, ok := strings.CutPrefix(value, "env:"); ok {
+		s, ok := FromEnv(name)
+		if !ok {
+			return Secret[string]{}, fmt.Errorf("camo: environment variable %s is not set", name)
+		}
+		return s, nil
+	}
+	return Obscure(value), nil
+}
@@ -0,0 +1,69 @@
+package camo
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSecretFlag(t *testing.T) {
+	path := writeSecretFile(t, "from-file\n", 0o600)
+	t.Setenv("CAMO_TEST_FLAG", "from-env")
+
+	for value, want := range map[string]string{
+		"literal":            "literal",
+		"@" + path:           "from-file",
+		"env:CAMO_TEST_FLAG": "from-env",
+	} {
+		var f SecretFlag
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&f, "password", "")
+		if err := fs.Parse([]string{"-password", value}); err != nil {
+			t.Fatalf("%s: %v", value, err)
+		}
+		if got := f.Secret.Reveal(); got != want {
+			t.Errorf("%s: Reveal() = %q; want %q", value, got, want)
+		}
+		if got := f.Get(); got != Obscure(want) {
+			t.Errorf("%s: Get() = %v", value, got)
+		}
+	}
+	if _, ok := os.LookupEnv("CAMO_TEST_FLAG"); ok {
+		t.Errorf("expected the variable to be unset")
+	}
+}
+
+func TestSecretFlagErrors(t *testing.T) {
+	values := []string{"@/does/not/exist", "env:CAMO_TEST_MISSING"}
+	if runtime.GOOS != "windows" {
+		values = append(values, "@"+writeSecretFile(t, "x", 0o644))
+	}
+	for _, value := range values {
+		var f SecretFlag
+		if err := f.Set(value); err == nil {
+			t.Errorf("%s: expected an error", value)
+		}
+	}
+}
+
+func TestSecretFlagUsage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+	fs.Var(&SecretFlag{}, "token", "API `token`")
+	fs.Var(&SecretFlag{Secret: Obscure("hunter2")}, "password", "database password")
+	fs.PrintDefaults()
+	usage := out.String()
+	if strings.Contains(usage, "hunter2") {
+		t.Errorf("usage contains the secret:\n%s", usage)
+	}
+	if !strings.Contains(usage, "(default REDACTED)") {
+		t.Errorf("expected a redacted default in usage:\n%s", usage)
+	}
+	if strings.Count(usage, "default") != 1 {
+		t.Errorf("expected no default for an unset flag:\n%s", usage)
+	}
+}
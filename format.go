@@ -0,0 +1,28 @@
+package camo
+
+import "fmt"
+
+// String implements fmt.Stringer. It returns a redacted representation of the
+// Secret that includes its type, such as "camo.Secret[string](REDACTED)".
+func (s Secret[O]) String() string {
+	var zero O
+	switch any(zero).(type) {
+	case string:
+		return "camo.Secret[string](" + Redacted + ")"
+	default:
+		return "camo.Secret[[]byte](" + Redacted + ")"
+	}
+}
+
+// GoString implements fmt.GoStringer, so that the %#v verb also prints the
+// redacted representation instead of the internals of the Secret.
+func (s Secret[O]) GoString() string {
+	return s.String()
+}
+
+// Format implements fmt.Formatter, so that every verb and flag prints the
+// redacted representation. Width is honored so that Secrets can be aligned
+// like any other string.
+func (s Secret[O]) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), s.String())
+}
@@ -0,0 +1,56 @@
+package camo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	type wrapper struct {
+		S Secret[string]
+	}
+
+	str := Obscure("hunter2")
+	bs := Obscure([]byte("hunter2"))
+	cases := []struct {
+		format string
+		arg    any
+		want   string
+	}{
+		{"%v", str, "camo.Secret[string](REDACTED)"},
+		{"%+v", str, "camo.Secret[string](REDACTED)"},
+		{"%#v", str, "camo.Secret[string](REDACTED)"},
+		{"%s", str, "camo.Secret[string](REDACTED)"},
+		{"%q", str, "camo.Secret[string](REDACTED)"},
+		{"%x", str, "camo.Secret[string](REDACTED)"},
+		{"%d", str, "camo.Secret[string](REDACTED)"},
+		{"%v", bs, "camo.Secret[[]byte](REDACTED)"},
+		{"%v", Secret[string]{}, "camo.Secret[string](REDACTED)"},
+		{"%v", wrapper{S: str}, "{camo.Secret[string](REDACTED)}"},
+		{"%+v", wrapper{S: str}, "{S:camo.Secret[string](REDACTED)}"},
+		{"%#v", wrapper{S: str}, "camo.wrapper{S:camo.Secret[string](REDACTED)}"},
+		{"%32v|", str, "   camo.Secret[string](REDACTED)|"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.format, func(t *testing.T) {
+			got := fmt.Sprintf(tc.format, tc.arg)
+			if got != tc.want {
+				t.Errorf("got = %q; want %q", got, tc.want)
+			}
+			if strings.Contains(got, "hunter2") {
+				t.Errorf("formatted output contains secret content")
+			}
+		})
+	}
+}
+
+func TestStringers(t *testing.T) {
+	s := Obscure("hunter2")
+	if got, want := s.String(), "camo.Secret[string](REDACTED)"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+	if got, want := s.GoString(), "camo.Secret[string](REDACTED)"; got != want {
+		t.Errorf("GoString() = %q; want %q", got, want)
+	}
+}
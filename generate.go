@@ -0,0 +1,15 @@
+package camo
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements testing/quick.Generator, so that property-based tests
+// using testing/quick can generate Secrets directly. The generated Secrets
+// are valid, with random content of up to size bytes.
+func (Secret[O]) Generate(rand *rand.Rand, size int) reflect.Value {
+	buf := make([]byte, rand.Intn(size+1))
+	rand.Read(buf)
+	return reflect.ValueOf(obscureOwned[O](buf))
+}
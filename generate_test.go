@@ -0,0 +1,27 @@
+package camo
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerate(t *testing.T) {
+	f := func(s Secret[string], b Secret[[]byte]) bool {
+		return s.Valid() && b.Valid() && ContentEqual(b, b.AsString()) && Obscure(s.Reveal()) == s
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	lengths := make(map[int]bool)
+	f2 := func(s Secret[[]byte]) bool {
+		lengths[s.Len()] = true
+		return s.Len() <= 50
+	}
+	if err := quick.Check(f2, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+	if len(lengths) < 10 {
+		t.Errorf("got only %d distinct lengths", len(lengths))
+	}
+}
@@ -1,3 +1,10 @@
 module github.com/rbranson/camo
 
-go 1.21
+go 1.24
+
+require (
+	golang.org/x/crypto v0.40.0
+	golang.org/x/sys v0.34.0
+	golang.org/x/term v0.33.0
+	golang.org/x/text v0.27.0
+)
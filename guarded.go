@@ -0,0 +1,32 @@
+package camo
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/rbranson/camo/internal/guard"
+)
+
+// obscureGuarded places the content in a guarded buffer, or returns false if
+// one couldn't be allocated.
+func obscureGuarded[O Obscurable](content []byte, o options) (Secret[O], bool) {
+	buf, err := guard.New(len(content))
+	if err != nil {
+		return Secret[O]{}, false
+	}
+	if o.noDump {
+		_ = buf.ExcludeFromDumps()
+	}
+	copy(buf.Bytes(), content)
+	if err := buf.Freeze(); err != nil {
+		buf.Destroy()
+		return Secret[O]{}, false
+	}
+	b := &box{
+		content: unsafe.String(unsafe.SliceData(buf.Bytes()), len(content)),
+		locked:  buf.Locked(),
+		policy:  o.policy,
+	}
+	runtime.AddCleanup(b, (*guard.Buffer).Destroy, buf)
+	return newSecret[O](b), true
+}
@@ -0,0 +1,40 @@
+package camo
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/rbranson/camo/internal/guard"
+)
+
+func TestObscureGuarded(t *testing.T) {
+	s := Obscure([]byte("hunter2"), Guarded(), ExcludeFromDumps())
+	buf, err := guard.New(0)
+	if err != nil {
+		t.Skipf("unable to allocate guarded memory: %v", err)
+	}
+	buf.Destroy()
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if s != Obscure([]byte("hunter2")) {
+		t.Errorf("expected guarded secret to equal an unguarded one with the same content")
+	}
+	if canLock() && !s.Locked() {
+		t.Errorf("expected guarded memory to be locked")
+	}
+	if got := Obscure("", Guarded()).Reveal(); got != "" {
+		t.Errorf("Reveal() = %q; want empty", got)
+	}
+}
+
+func TestObscureGuardedRevealOutlivesSecret(t *testing.T) {
+	revealed := Obscure("hunter2", Guarded()).Reveal()
+	for range 3 {
+		runtime.GC()
+	}
+	if revealed != "hunter2" {
+		t.Errorf("revealed = %q; want %q", revealed, "hunter2")
+	}
+}
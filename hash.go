@@ -0,0 +1,89 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/rbranson/camo/internal/siphash"
+)
+
+// Unhashed skips hashing the content of the secret, so that nothing derived
+// from the content is stored next to it, where tools that dump memory or
+// unexported fields could use it to brute-force low-entropy content, such as
+// passwords, offline. The secret is given a random hash instead, so it is
+// only equal to copies of itself, and not to other secrets with the same
+// content.
+func Unhashed() Option {
+	return func(o *options) {
+		o.hash = func(string) uint64 {
+			return rand.Uint64()
+		}
+	}
+}
+
+// KeyedHash computes the hash of the content of the secret, which Secrets
+// are compared by, as HMAC-SHA256 with key, truncated to 64 bits, instead of
+// with a seed generated for each process (see also SipHashed).
+// Brute-forcing the content from the hash then requires the key as well.
+// Secrets are only equal to those with the same content whose hash was
+// computed with the same key, and as the hash doesn't depend on the process,
+// it can be compared across processes.
+func KeyedHash(key Secret[[]byte]) Option {
+	return func(o *options) {
+		o.hash = func(content string) uint64 {
+			// Hashing isn't an extraction of the key, so it doesn't go
+			// through NewHMAC, which would report it.
+			defer runtime.KeepAlive(key)
+			mac := hmac.New(sha256.New, key.view())
+			mac.Write(unsafe.Slice(unsafe.StringData(content), len(content)))
+			return binary.BigEndian.Uint64(mac.Sum(nil))
+		}
+	}
+}
+
+// SipHashed computes the hash of the content of the secret, which Secrets are
+// compared by, as SipHash-2-4 with key, which must be 16 bytes long. Like
+// KeyedHash, it makes equality stable across processes that use the same
+// key, such as for deduplicating secrets in a distributed cache, but is
+// faster, at the cost of a weaker guarantee against brute-forcing if the key
+// is also exposed. It panics if the key isn't 16 bytes long.
+func SipHashed(key Secret[[]byte]) Option {
+	if key.Len() != 16 {
+		panic("camo: SipHashed requires a 16-byte key")
+	}
+	return func(o *options) {
+		o.hash = func(content string) uint64 {
+			defer runtime.KeepAlive(key)
+			k := key.view()
+			return siphash.Sum64(binary.LittleEndian.Uint64(k), binary.LittleEndian.Uint64(k[8:]), unsafe.Slice(unsafe.StringData(content), len(content)))
+		}
+	}
+}
+
+var seededHash atomic.Pointer[func(string) uint64]
+
+// SetHashSeed makes the hashes of the content of Secrets created afterwards
+// deterministic, computing them as SipHash-2-4 with seed instead of with a
+// seed generated for each process, so that tests and golden files that
+// depend on them, such as through the order of maps keyed by Secrets or
+// Hash64, are reproducible. It returns a function that restores the previous
+// hash function. Secrets keep the hash they were created with, so those
+// created before or after don't compare equal to those created in between.
+//
+// The hash function is process-wide, and makes brute-forcing the content
+// from the hashes easier, so SetHashSeed is only meant for tests, such as
+// through camotest.WithSeed.
+func SetHashSeed(seed uint64) (restore func()) {
+	hash := func(content string) uint64 {
+		return siphash.Sum64(seed, 0, unsafe.Slice(unsafe.StringData(content), len(content)))
+	}
+	prev := seededHash.Swap(&hash)
+	return func() {
+		seededHash.Store(prev)
+	}
+}
@@ -0,0 +1,87 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/rbranson/camo/internal/siphash"
+)
+
+func TestUnhashed(t *testing.T) {
+	a := Obscure("hunter2", Unhashed())
+	b := Obscure("hunter2", Unhashed())
+	if a == b || a == Obscure("hunter2") {
+		t.Errorf("expected unhashed secrets to be unequal to others with the same content")
+	}
+	if c := a; c != a {
+		t.Errorf("expected an unhashed secret to equal its copies")
+	}
+	if a.Reveal() != "hunter2" || !ContentEqual(a, b) {
+		t.Errorf("expected the content to be kept")
+	}
+	if g := Obscure("hunter2", Unhashed(), Guarded()); g == Obscure("hunter2", Guarded()) {
+		t.Errorf("expected a guarded unhashed secret to be unequal to others")
+	}
+}
+
+func TestKeyedHash(t *testing.T) {
+	key := Obscure([]byte("hash key"))
+	a := Obscure("hunter2", KeyedHash(key))
+	if a != Obscure("hunter2", KeyedHash(key)) {
+		t.Errorf("expected secrets hashed with the same key to be equal")
+	}
+	if a == Obscure("hunter2") || a == Obscure("hunter2", KeyedHash(Obscure([]byte("other key")))) {
+		t.Errorf("expected secrets hashed differently to be unequal")
+	}
+	if a == Obscure("hunter3", KeyedHash(key)) {
+		t.Errorf("expected different content to be unequal")
+	}
+	if l := ObscureLocked("hunter2", KeyedHash(key)); l != a {
+		t.Errorf("expected a locked secret hashed with the key to be equal")
+	}
+
+	mac := hmac.New(sha256.New, []byte("hash key"))
+	mac.Write([]byte("hunter2"))
+	if got, want := a.Hash64(), binary.BigEndian.Uint64(mac.Sum(nil)); got != want {
+		t.Errorf("Hash64() = %x; want %x", got, want)
+	}
+}
+
+func TestSipHashed(t *testing.T) {
+	key := Obscure([]byte("0123456789abcdef"))
+	a := Obscure("hunter2", SipHashed(key))
+	if a != Obscure([]byte("hunter2"), SipHashed(key)).AsString() {
+		t.Errorf("expected secrets hashed with the same key to be equal")
+	}
+	if a == Obscure("hunter2") || a == Obscure("hunter2", KeyedHash(key)) {
+		t.Errorf("expected secrets hashed differently to be unequal")
+	}
+	if a == Obscure("hunter3", SipHashed(key)) {
+		t.Errorf("expected different content to be unequal")
+	}
+	want := siphash.Sum64(0x3736353433323130, 0x6665646362613938, []byte("hunter2"))
+	if got := a.Hash64(); got != want {
+		t.Errorf("Hash64() = %x; want %x", got, want)
+	}
+	if _, ok := capturePanic(func() { SipHashed(Obscure([]byte("short"))) }); !ok {
+		t.Errorf("expected SipHashed() with a short key to panic")
+	}
+}
+
+func TestSetHashSeed(t *testing.T) {
+	before := Obscure("hunter2")
+	restore := SetHashSeed(42)
+	got := Obscure("hunter2")
+	if want := siphash.Sum64(42, 0, []byte("hunter2")); got.Hash64() != want {
+		t.Errorf("Hash64() = %x; want %x", got.Hash64(), want)
+	}
+	if ObscureSealed("hunter2") != got.Seal() {
+		t.Errorf("expected sealed secrets to be hashed with the seed")
+	}
+	restore()
+	if after := Obscure("hunter2"); after != before || after == got {
+		t.Errorf("expected the default hash to be restored")
+	}
+}
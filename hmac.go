@@ -0,0 +1,34 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"hash"
+	"runtime"
+)
+
+// HMAC returns the HMAC of data computed with the hash function h, using the
+// secret as the key. It panics if the secret is zero.
+func (s Secret[O]) HMAC(h func() hash.Hash, data []byte) []byte {
+	mac := s.newHMAC("HMAC", h)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// NewHMAC returns a new hash.Hash computing an HMAC with the hash function h,
+// using the secret as the key. Data can be streamed into it with Write. It
+// panics if the secret is zero.
+func (s Secret[O]) NewHMAC(h func() hash.Hash) hash.Hash {
+	return s.newHMAC("NewHMAC", h)
+}
+
+func (s Secret[O]) newHMAC(method string, h func() hash.Hash) hash.Hash {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero(method)
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed(method)
+	// hmac.New derives its own padded copies of the key, so it can be given
+	// the content without copying it first.
+	return hmac.New(h, s.view())
+}
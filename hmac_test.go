@@ -0,0 +1,48 @@
+package camo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHMAC(t *testing.T) {
+	// RFC 4231, test case 2.
+	key := Obscure("Jefe")
+	data := []byte("what do ya want for nothing?")
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+
+	if got := hex.EncodeToString(key.HMAC(sha256.New, data)); got != want {
+		t.Errorf("HMAC = %s; want %s", got, want)
+	}
+
+	mac := Obscure([]byte("Jefe")).NewHMAC(sha256.New)
+	mac.Write(data[:10])
+	mac.Write(data[10:])
+	if got := hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("NewHMAC = %s; want %s", got, want)
+	}
+}
+
+func TestHMACVerify(t *testing.T) {
+	key := Obscure("webhook-secret")
+	body := []byte(`{"event":"push"}`)
+	sig := key.HMAC(sha256.New, body)
+	if !hmac.Equal(sig, key.HMAC(sha256.New, body)) {
+		t.Errorf("expected signatures to match")
+	}
+	if hmac.Equal(sig, Obscure("other").HMAC(sha256.New, body)) {
+		t.Errorf("expected signatures with different keys to differ")
+	}
+}
+
+func TestPanicOnZeroHMAC(t *testing.T) {
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.HMAC(sha256.New, nil) }); !ok {
+		t.Errorf("expected zero.HMAC() to panic")
+	}
+	if _, ok := capturePanic(func() { zero.NewHMAC(sha256.New) }); !ok {
+		t.Errorf("expected zero.NewHMAC() to panic")
+	}
+}
@@ -0,0 +1,118 @@
+// Package guard allocates buffers outside of the Go heap that are surrounded
+// by inaccessible guard pages, so the garbage collector never copies their
+// content around, and overruns past either end of the buffer fault
+// immediately.
+//
+// A buffer is placed at the end of its data pages, right up against the rear
+// guard page. The space in front of it is filled with a canary, which is
+// checked when the buffer is destroyed to detect underruns too small to reach
+// the front guard page.
+//
+//	| guard | canary ... content | guard |
+package guard
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/rbranson/camo/internal/mem"
+)
+
+// canaryMin is the minimum number of canary bytes in front of a buffer.
+const canaryMin = 16
+
+var canary = func() [64]byte {
+	var c [64]byte
+	rand.Read(c[:])
+	return c
+}()
+
+// ErrCanary is the panic value used when a corrupted canary is found.
+var ErrCanary = errors.New("guard: canary corrupted, memory in front of a guarded buffer was overwritten")
+
+// Buffer is a guarded buffer. It must be destroyed with Destroy.
+type Buffer struct {
+	all    []byte
+	inner  []byte
+	canary []byte
+	data   []byte
+	locked bool
+}
+
+// New allocates a guarded buffer of n bytes. The memory is also locked into
+// RAM where possible, as reported by Locked.
+func New(n int) (*Buffer, error) {
+	page := mem.PageSize()
+	innerLen := mem.RoundUp(n + canaryMin)
+	all, err := mem.Map(page + innerLen + page)
+	if err != nil {
+		return nil, err
+	}
+	b := &Buffer{all: all, inner: all[page : page+innerLen]}
+	b.canary = b.inner[:innerLen-n]
+	b.data = b.inner[innerLen-n:]
+	if err := mem.Protect(all[:page], mem.NoAccess); err != nil {
+		mem.Unmap(all)
+		return nil, err
+	}
+	if err := mem.Protect(all[page+innerLen:], mem.NoAccess); err != nil {
+		mem.Unmap(all)
+		return nil, err
+	}
+	b.locked = mem.Lock(b.inner) == nil
+	for i := 0; i < len(b.canary); i += len(canary) {
+		copy(b.canary[i:], canary[:])
+	}
+	return b, nil
+}
+
+// Bytes returns the buffer. It must not be used after Destroy.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Locked reports if the buffer is locked into RAM.
+func (b *Buffer) Locked() bool {
+	return b.locked
+}
+
+// ExcludeFromDumps excludes the buffer from core dumps, where supported.
+func (b *Buffer) ExcludeFromDumps() error {
+	return mem.ExcludeFromDumps(b.inner)
+}
+
+// Freeze makes the buffer read-only.
+func (b *Buffer) Freeze() error {
+	return mem.Protect(b.inner, mem.ReadOnly)
+}
+
+// Destroy wipes and releases the buffer. It panics with ErrCanary if the
+// canary was corrupted, after the memory is released.
+func (b *Buffer) Destroy() {
+	// If the memory can't be made writable again, unmapping it is the best
+	// that can be done, as the kernel zeroes pages before reusing them.
+	intact := true
+	if mem.Protect(b.inner, mem.ReadWrite) == nil {
+		intact = b.canaryIntact()
+		clear(b.inner)
+	}
+	if b.locked {
+		mem.Unlock(b.inner)
+	}
+	mem.Unmap(b.all)
+	*b = Buffer{}
+	if !intact {
+		panic(ErrCanary)
+	}
+}
+
+func (b *Buffer) canaryIntact() bool {
+	for i := 0; i < len(b.canary); i += len(canary) {
+		c := b.canary[i:min(i+len(canary), len(b.canary))]
+		if subtle.ConstantTimeCompare(c, canary[:len(c)]) != 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package guard
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rbranson/camo/internal/mem"
+)
+
+func newBuffer(t *testing.T, n int) *Buffer {
+	t.Helper()
+	b, err := New(n)
+	if err != nil {
+		t.Skipf("unable to allocate guarded memory: %v", err)
+	}
+	return b
+}
+
+func TestBuffer(t *testing.T) {
+	for _, n := range []int{0, 1, 7, mem.PageSize() - canaryMin, mem.PageSize(), 3 * mem.PageSize()} {
+		b := newBuffer(t, n)
+		if got := len(b.Bytes()); got != n {
+			t.Errorf("len(Bytes()) = %d; want %d", got, n)
+		}
+		copy(b.Bytes(), bytes.Repeat([]byte{0xAA}, n))
+		if err := b.Freeze(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.Bytes(), bytes.Repeat([]byte{0xAA}, n)) {
+			t.Errorf("buffer content changed")
+		}
+		if len(b.canary) < canaryMin {
+			t.Errorf("len(canary) = %d; want at least %d", len(b.canary), canaryMin)
+		}
+		b.Destroy()
+	}
+}
+
+func TestBufferEndsAtGuardPage(t *testing.T) {
+	b := newBuffer(t, 5)
+	defer b.Destroy()
+	if got, want := len(b.all)-mem.PageSize(), len(b.inner)+mem.PageSize(); got != want {
+		t.Fatalf("rear guard page starts at %d; want %d", got, want)
+	}
+	end := &b.inner[len(b.inner)-1]
+	if &b.Bytes()[4] != end {
+		t.Errorf("expected the buffer to end right before the rear guard page")
+	}
+}
+
+func TestDestroyPanicsOnCorruptCanary(t *testing.T) {
+	b := newBuffer(t, 5)
+	b.canary[len(b.canary)-1] ^= 0xFF
+	defer func() {
+		if r := recover(); r != ErrCanary {
+			t.Errorf("recover() = %v; want ErrCanary", r)
+		}
+	}()
+	b.Destroy()
+}
@@ -0,0 +1,33 @@
+// Package mem provides page-granular memory that is allocated outside of the
+// Go heap, and the primitives for protecting it. The Go runtime never moves or
+// copies this memory, and it is never scanned by the garbage collector, so it
+// must not hold pointers to Go memory.
+//
+// All slices passed to the functions in this package must be page-aligned,
+// and must have been returned by Map or be a page-aligned subslice of one.
+package mem
+
+import "os"
+
+// Prot is a memory protection mode.
+type Prot int
+
+const (
+	NoAccess Prot = iota
+	ReadOnly
+	ReadWrite
+)
+
+// PageSize returns the size of a memory page.
+func PageSize() int {
+	return os.Getpagesize()
+}
+
+// RoundUp rounds n up to a whole number of pages, with a minimum of one page.
+func RoundUp(n int) int {
+	size := PageSize()
+	if n <= 0 {
+		return size
+	}
+	return (n + size - 1) &^ (size - 1)
+}
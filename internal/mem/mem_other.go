@@ -0,0 +1,31 @@
+//go:build !unix && !windows
+
+package mem
+
+import "errors"
+
+// Map allocates size bytes of readable and writable memory, which must be a
+// multiple of the page size.
+func Map(size int) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// Unmap releases memory returned by Map.
+func Unmap(b []byte) error {
+	return errors.ErrUnsupported
+}
+
+// Lock locks the memory into RAM so it is never written to swap.
+func Lock(b []byte) error {
+	return errors.ErrUnsupported
+}
+
+// Unlock reverses Lock.
+func Unlock(b []byte) error {
+	return errors.ErrUnsupported
+}
+
+// Protect sets the protection mode of the memory.
+func Protect(b []byte, p Prot) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,39 @@
+package mem
+
+import "testing"
+
+func TestRoundUp(t *testing.T) {
+	size := PageSize()
+	tests := []struct{ n, want int }{
+		{0, size},
+		{1, size},
+		{size, size},
+		{size + 1, 2 * size},
+	}
+	for _, tc := range tests {
+		if got := RoundUp(tc.n); got != tc.want {
+			t.Errorf("RoundUp(%d) = %d; want %d", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestMapProtect(t *testing.T) {
+	b, err := Map(RoundUp(1))
+	if err != nil {
+		t.Skipf("unable to map memory: %v", err)
+	}
+	b[0] = 42
+	if err := Protect(b, ReadOnly); err != nil {
+		t.Fatal(err)
+	}
+	if b[0] != 42 {
+		t.Errorf("b[0] = %d; want 42", b[0])
+	}
+	if err := Protect(b, ReadWrite); err != nil {
+		t.Fatal(err)
+	}
+	b[0] = 0
+	if err := Unmap(b); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package mem
+
+import "golang.org/x/sys/unix"
+
+// Map allocates size bytes of readable and writable memory, which must be a
+// multiple of the page size.
+func Map(size int) ([]byte, error) {
+	return unix.Mmap(-1, 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+}
+
+// Unmap releases memory returned by Map.
+func Unmap(b []byte) error {
+	return unix.Munmap(b)
+}
+
+// Lock locks the memory into RAM so it is never written to swap.
+func Lock(b []byte) error {
+	return unix.Mlock(b)
+}
+
+// Unlock reverses Lock.
+func Unlock(b []byte) error {
+	return unix.Munlock(b)
+}
+
+// Protect sets the protection mode of the memory.
+func Protect(b []byte, p Prot) error {
+	var prot int
+	switch p {
+	case NoAccess:
+		prot = unix.PROT_NONE
+	case ReadOnly:
+		prot = unix.PROT_READ
+	case ReadWrite:
+		prot = unix.PROT_READ | unix.PROT_WRITE
+	}
+	return unix.Mprotect(b, prot)
+}
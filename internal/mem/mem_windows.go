@@ -0,0 +1,56 @@
+//go:build windows
+
+package mem
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func addr(b []byte) (uintptr, uintptr) {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(b))), uintptr(len(b))
+}
+
+// Map allocates size bytes of readable and writable memory, which must be a
+// multiple of the page size.
+func Map(size int) ([]byte, error) {
+	p, err := windows.VirtualAlloc(0, uintptr(size), windows.MEM_COMMIT|windows.MEM_RESERVE, windows.PAGE_READWRITE)
+	if err != nil {
+		return nil, err
+	}
+	// The memory is outside of the Go heap, so the address is stable.
+	return unsafe.Slice((*byte)(unsafe.Add(nil, p)), size), nil
+}
+
+// Unmap releases memory returned by Map.
+func Unmap(b []byte) error {
+	p, _ := addr(b)
+	return windows.VirtualFree(p, 0, windows.MEM_RELEASE)
+}
+
+// Lock locks the memory into RAM so it is never written to swap.
+func Lock(b []byte) error {
+	return windows.VirtualLock(addr(b))
+}
+
+// Unlock reverses Lock.
+func Unlock(b []byte) error {
+	return windows.VirtualUnlock(addr(b))
+}
+
+// Protect sets the protection mode of the memory.
+func Protect(b []byte, p Prot) error {
+	var prot uint32
+	switch p {
+	case NoAccess:
+		prot = windows.PAGE_NOACCESS
+	case ReadOnly:
+		prot = windows.PAGE_READONLY
+	case ReadWrite:
+		prot = windows.PAGE_READWRITE
+	}
+	p0, size := addr(b)
+	var old uint32
+	return windows.VirtualProtect(p0, size, prot, &old)
+}
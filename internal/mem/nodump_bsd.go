@@ -0,0 +1,10 @@
+//go:build freebsd || dragonfly
+
+package mem
+
+import "golang.org/x/sys/unix"
+
+// ExcludeFromDumps excludes the memory from core dumps, where supported.
+func ExcludeFromDumps(b []byte) error {
+	return unix.Madvise(b, unix.MADV_NOCORE)
+}
@@ -0,0 +1,55 @@
+package mem
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestExcludeFromDumps(t *testing.T) {
+	b, err := Map(PageSize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Unmap(b)
+	if err := ExcludeFromDumps(b); err != nil {
+		t.Fatal(err)
+	}
+	flags, err := vmFlags(uintptr(unsafe.Pointer(unsafe.SliceData(b))))
+	if err != nil {
+		t.Skipf("unable to read mapping flags: %v", err)
+	}
+	if !strings.Contains(" "+flags+" ", " dd ") {
+		t.Errorf("expected mapping to be excluded from dumps, got flags %q", flags)
+	}
+}
+
+// vmFlags returns the VmFlags of the mapping containing addr, as reported by
+// /proc/self/smaps.
+func vmFlags(addr uintptr) (string, error) {
+	f, err := os.Open("/proc/self/smaps")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	found := false
+	for sc.Scan() {
+		line := sc.Text()
+		var start, end uintptr
+		if _, err := fmt.Sscanf(line, "%x-%x", &start, &end); err == nil {
+			found = start <= addr && addr < end
+			continue
+		}
+		if found && strings.HasPrefix(line, "VmFlags:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "VmFlags:")), nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no mapping for %#x", addr)
+}
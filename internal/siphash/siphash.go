@@ -0,0 +1,62 @@
+// Package siphash implements SipHash-2-4, a keyed hash function designed for
+// hash tables, as described in "SipHash: a fast short-input PRF" by
+// Jean-Philippe Aumasson and Daniel J. Bernstein.
+package siphash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Sum64 returns the SipHash-2-4 of p with the 128-bit key given as two
+// little-endian halves, k0 and k1.
+func Sum64(k0, k1 uint64, p []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	n := len(p)
+	for ; len(p) >= 8; p = p[8:] {
+		m := binary.LittleEndian.Uint64(p)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+
+	// The last block holds the remaining bytes and the length of the input
+	// in its most significant byte.
+	m := uint64(n) << 56
+	for i, b := range p {
+		m |= uint64(b) << (8 * i)
+	}
+	v3 ^= m
+	round()
+	round()
+	v0 ^= m
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
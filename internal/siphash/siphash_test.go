@@ -0,0 +1,28 @@
+package siphash
+
+import "testing"
+
+// The vectors are from the reference implementation, with the key 00 01 02
+// ... 0f and the input 00 01 02 ... of the given length.
+func TestSum64(t *testing.T) {
+	vectors := []struct {
+		n    int
+		want uint64
+	}{
+		{0, 0x726fdb47dd0e0e31},
+		{1, 0x74f839c593dc67fd},
+		{7, 0xab0200f58b01d137},
+		{8, 0x93f5f5799a932462},
+		{15, 0xa129ca6149be45e5},
+		{63, 0x958a324ceb064572},
+	}
+	p := make([]byte, 64)
+	for i := range p {
+		p[i] = byte(i)
+	}
+	for _, v := range vectors {
+		if got := Sum64(0x0706050403020100, 0x0f0e0d0c0b0a0908, p[:v.n]); got != v.want {
+			t.Errorf("Sum64 of %d bytes = %#016x; want %#016x", v.n, got, v.want)
+		}
+	}
+}
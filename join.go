@@ -0,0 +1,118 @@
+package camo
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Concat returns a Secret holding the concatenation of the content of parts,
+// such as for composite tokens, without revealing any of them to the caller.
+// Combining the parts counts as extracting the content of each of them (see
+// SetRevealHook), and the result keeps their policies: it is a canary if any
+// of the parts is, and has the label and hash options of the first part that
+// has them. It panics if any of the parts is zero, or if the parts are
+// restricted by AllowCallers to different packages.
+func Concat[O Obscurable](parts ...Secret[O]) Secret[O] {
+	return join("Concat", nil, parts)
+}
+
+// Join returns a Secret holding the content of parts separated by sep, such
+// as for connection strings, without revealing any of them to the caller. The
+// parts are combined as by Concat, with the same panics.
+func Join[O Obscurable](sep []byte, parts ...Secret[O]) Secret[O] {
+	return join("Join", sep, parts)
+}
+
+func join[O Obscurable](op string, sep []byte, parts []Secret[O]) Secret[O] {
+	n := 0
+	for i, p := range parts {
+		if !p.Valid() {
+			panicZero(op)
+		}
+		if i > 0 {
+			n += len(sep)
+		}
+		n += len(p.str())
+	}
+	pol := mergePolicies(op, parts)
+	buf := make([]byte, 0, n)
+	for i, p := range parts {
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+		b := p.box()
+		b.revealed(op)
+		buf = append(buf, b.content...)
+	}
+	runtime.KeepAlive(parts)
+	return obscureOwned[O](buf, withPolicy(pol))
+}
+
+// mergePolicies returns the policy of a secret combining the content of
+// parts, as described by Concat.
+func mergePolicies[O Obscurable](op string, parts []Secret[O]) policy {
+	var pol policy
+	var canaries []func(CanaryEvent)
+	for _, p := range parts {
+		b := p.box()
+		if pol.label == "" {
+			pol.label = b.label
+		}
+		if pol.hash == nil {
+			pol.hash = b.hash
+		}
+		if b.canary != nil {
+			canaries = append(canaries, b.canary)
+		}
+		if b.allowed != nil {
+			if pol.allowed != nil && !slices.Equal(pol.allowed, b.allowed) {
+				panic("camo: " + op + " of secrets restricted to different callers")
+			}
+			pol.allowed = b.allowed
+		}
+	}
+	switch len(canaries) {
+	case 0:
+	case 1:
+		pol.canary = canaries[0]
+	default:
+		pol.canary = func(e CanaryEvent) {
+			for _, c := range canaries {
+				c(e)
+			}
+		}
+	}
+	return pol
+}
+
+// Split slices the secret into the parts separated by sep, as with
+// strings.Split, and returns them as new Secrets, such as for credentials
+// delivered as "user:pass". The parts keep the label and policies of s, such
+// as AllowCallers. It panics if the secret is zero.
+func (s Secret[O]) Split(sep []byte) []Secret[O] {
+	return s.split("Split", sep, -1)
+}
+
+// SplitN is like Split, but returns at most n parts, as with
+// strings.SplitN, so that the last part can contain the separator, such as a
+// password after the first colon.
+func (s Secret[O]) SplitN(sep []byte, n int) []Secret[O] {
+	return s.split("SplitN", sep, n)
+}
+
+func (s Secret[O]) split(op string, sep []byte, n int) []Secret[O] {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero(op)
+	}
+	defer runtime.KeepAlive(s)
+	b := s.box()
+	b.revealed(op)
+	parts := strings.SplitN(b.content, string(sep), n)
+	secrets := make([]Secret[O], len(parts))
+	for i, p := range parts {
+		secrets[i] = convert[O](Obscure(p, withPolicy(b.policy)))
+	}
+	return secrets
+}
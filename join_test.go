@@ -0,0 +1,114 @@
+package camo
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	got := Concat(Obscure("sk_"), Obscure("live_"), Obscure("1234"))
+	if got != Obscure("sk_live_1234") {
+		t.Errorf("got = %q; want %q", got.Reveal(), "sk_live_1234")
+	}
+	if got := Concat[[]byte](); !got.Valid() || len(got.Reveal()) != 0 {
+		t.Errorf("expected an empty Secret from no parts")
+	}
+	if _, ok := capturePanic(func() { Concat(Obscure("a"), Secret[string]{}) }); !ok {
+		t.Errorf("expected Concat() with a zero part to panic")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	got := Join([]byte(":"), Obscure([]byte("user")), Obscure([]byte("pass")))
+	if got != Obscure([]byte("user:pass")) {
+		t.Errorf("got = %q; want %q", got.Reveal(), "user:pass")
+	}
+	if got := Join([]byte(":"), Obscure("user")); got != Obscure("user") {
+		t.Errorf("got = %q; want %q", got.Reveal(), "user")
+	}
+	if _, ok := capturePanic(func() { Join(nil, Secret[string]{}) }); !ok {
+		t.Errorf("expected Join() with a zero part to panic")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	s := Obscure([]byte("user:pa:ss"))
+	got := s.Split([]byte(":"))
+	want := []Secret[[]byte]{Obscure([]byte("user")), Obscure([]byte("pa")), Obscure([]byte("ss"))}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %d parts; want %v", len(got), want)
+	}
+	got = s.SplitN([]byte(":"), 2)
+	want = []Secret[[]byte]{Obscure([]byte("user")), Obscure([]byte("pa:ss"))}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %d parts; want %v", len(got), want)
+	}
+	if got := Obscure("token").Split([]byte(":")); len(got) != 1 || got[0] != Obscure("token") {
+		t.Errorf("expected a single part without the separator")
+	}
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.Split([]byte(":")) }); !ok {
+		t.Errorf("expected zero.Split() to panic")
+	}
+}
+
+func TestSplitKeepsPolicy(t *testing.T) {
+	var events []CanaryEvent
+	s := Canary("user:pass", func(e CanaryEvent) { events = append(events, e) }, Labeled("db"))
+	parts := s.SplitN([]byte(":"), 2)
+	if len(events) != 1 || events[0].Op != "SplitN" || filepath.Base(events[0].File) != "join_test.go" {
+		t.Fatalf("events = %+v; want a SplitN from join_test.go", events)
+	}
+	for i, p := range parts {
+		if !p.IsCanary() || p.Label() != "db" {
+			t.Errorf("parts[%d] doesn't keep the label and policies of the secret", i)
+		}
+	}
+	parts[1].Reveal()
+	if len(events) != 2 {
+		t.Errorf("expected revealing a part to trigger the canary")
+	}
+
+	var violations []PolicyViolation
+	SetPolicyViolationHandler(func(v PolicyViolation) { violations = append(violations, v) })
+	defer SetPolicyViolationHandler(nil)
+	Obscure("user:pass", AllowCallers("example.com/app")).Split([]byte(":"))
+	if len(violations) != 1 || violations[0].Op != "Split" {
+		t.Errorf("violations = %+v; want one for Split", violations)
+	}
+}
+
+func TestConcatKeepsPolicy(t *testing.T) {
+	var infos []RevealInfo
+	SetRevealHook(func(info RevealInfo) { infos = append(infos, info) })
+	defer SetRevealHook(nil)
+
+	var accesses int
+	canary := Canary("sk_", func(CanaryEvent) { accesses++ }, Labeled("prefix"))
+	got := Concat(canary, Obscure("live_", Labeled("env")), Obscure("1234"))
+	if len(infos) != 3 || infos[0].Op != "Concat" || filepath.Base(infos[2].File) != "join_test.go" {
+		t.Errorf("infos = %+v; want a Concat from join_test.go for each part", infos)
+	}
+	if !got.IsCanary() || got.Label() != "prefix" || accesses != 1 {
+		t.Errorf("got IsCanary() = %v, label %q and %d accesses", got.IsCanary(), got.Label(), accesses)
+	}
+	got.Reveal()
+	if accesses != 2 {
+		t.Errorf("expected revealing the result to trigger the canary")
+	}
+
+	var violations []PolicyViolation
+	SetPolicyViolationHandler(func(v PolicyViolation) { violations = append(violations, v) })
+	defer SetPolicyViolationHandler(nil)
+	restricted := Join([]byte(":"), Obscure("user"), Obscure("pass", AllowCallers("example.com/app")))
+	restricted.Reveal()
+	if len(violations) != 2 || violations[0].Op != "Join" || violations[1].Op != "Reveal" {
+		t.Errorf("violations = %+v; want one for Join and one for Reveal", violations)
+	}
+	if _, ok := capturePanic(func() {
+		Concat(Obscure("a", AllowCallers("example.com/a")), Obscure("b", AllowCallers("example.com/b")))
+	}); !ok {
+		t.Errorf("expected Concat() of secrets restricted to different callers to panic")
+	}
+}
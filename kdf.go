@@ -0,0 +1,42 @@
+package camo
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"hash"
+	"runtime"
+)
+
+// DeriveHKDF derives a key of length n from the secret with HKDF (RFC 5869)
+// using the hash function h, and returns it as a new Secret. The salt and
+// info parameters are optional. It panics if the secret is zero.
+func (s Secret[O]) DeriveHKDF(h func() hash.Hash, salt []byte, info string, n int) (Secret[[]byte], error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("DeriveHKDF")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("DeriveHKDF")
+	key, err := hkdf.Key(h, s.view(), salt, info, n)
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	return obscureOwned[[]byte](key), nil
+}
+
+// DerivePBKDF2 derives a key of length n from the secret with PBKDF2 (RFC
+// 8018) using iter iterations of HMAC with the hash function h, and returns
+// it as a new Secret. It panics if the secret is zero.
+func (s Secret[O]) DerivePBKDF2(salt []byte, iter, n int, h func() hash.Hash) (Secret[[]byte], error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("DerivePBKDF2")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("DerivePBKDF2")
+	key, err := pbkdf2.Key(h, s.str(), salt, iter, n)
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	return obscureOwned[[]byte](key), nil
+}
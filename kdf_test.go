@@ -0,0 +1,52 @@
+package camo
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDeriveHKDF(t *testing.T) {
+	// RFC 5869, test case 1.
+	ikm, _ := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	want := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+
+	got, err := Obscure(ikm).DeriveHKDF(sha256.New, salt, string(info), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g := hex.EncodeToString(got.Reveal()); g != want {
+		t.Errorf("got = %s; want %s", g, want)
+	}
+}
+
+func TestDerivePBKDF2(t *testing.T) {
+	// RFC 6070, test case 2.
+	want := "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"
+	got, err := Obscure("password").DerivePBKDF2([]byte("salt"), 2, 20, sha1.New)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g := hex.EncodeToString(got.Reveal()); g != want {
+		t.Errorf("got = %s; want %s", g, want)
+	}
+}
+
+func TestDeriveHKDFError(t *testing.T) {
+	if _, err := Obscure("x").DeriveHKDF(sha256.New, nil, "", 255*sha256.Size+1); err == nil {
+		t.Errorf("expected error for excessive length")
+	}
+}
+
+func TestPanicOnZeroDerive(t *testing.T) {
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.DeriveHKDF(sha256.New, nil, "", 32) }); !ok {
+		t.Errorf("expected zero.DeriveHKDF() to panic")
+	}
+	if _, ok := capturePanic(func() { zero.DerivePBKDF2(nil, 1, 32, sha256.New) }); !ok {
+		t.Errorf("expected zero.DerivePBKDF2() to panic")
+	}
+}
@@ -0,0 +1,156 @@
+package camo
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Keyring is a named collection of secrets, which gives an application one
+// place to hold and rotate its credentials. The secrets may be of any type,
+// and are returned as Secret[string] by Get, or as they were stored by
+// Lookup.
+//
+// The zero value is an empty Keyring. A Keyring must not be copied after
+// first use. It is safe for concurrent use.
+type Keyring struct {
+	mu      sync.RWMutex
+	secrets map[string]AnySecret
+
+	// subs holds the subscriptions made by Rotate, by name.
+	subs map[string]*keyringSub
+}
+
+// keyringSub is a subscription to the changes of a rotating secret.
+type keyringSub struct {
+	cancel func()
+}
+
+// Get returns the secret with the given name as a Secret[string], and
+// whether it exists.
+func (k *Keyring) Get(name string) (Secret[string], bool) {
+	s, ok := k.Lookup(name)
+	if !ok {
+		return Secret[string]{}, false
+	}
+	return s.AsString(), true
+}
+
+// Lookup returns the secret with the given name, with the type it was stored
+// with, and whether it exists.
+func (k *Keyring) Lookup(name string) (AnySecret, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	s, ok := k.secrets[name]
+	return s, ok
+}
+
+// Set stores s under the given name, replacing any existing secret, and
+// stops keeping it up to date if it was added with Rotate. A nil s is stored
+// as a zero Secret[string].
+func (k *Keyring) Set(name string, s AnySecret) {
+	if s == nil {
+		s = Secret[string]{}
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.unsubscribeLocked(name)
+	if k.secrets == nil {
+		k.secrets = make(map[string]AnySecret)
+	}
+	k.secrets[name] = s
+}
+
+// Delete removes the secret with the given name, and stops keeping it up to
+// date if it was added with Rotate.
+func (k *Keyring) Delete(name string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.secrets, name)
+	k.unsubscribeLocked(name)
+}
+
+// unsubscribeLocked cancels the subscription made by Rotate for name, if
+// any. It must be called with k.mu held.
+func (k *Keyring) unsubscribeLocked(name string) {
+	if sub, ok := k.subs[name]; ok {
+		sub.cancel()
+		delete(k.subs, name)
+	}
+}
+
+// Names returns the names of the secrets in sorted order.
+func (k *Keyring) Names() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return slices.Sorted(maps.Keys(k.secrets))
+}
+
+// Snapshot returns a copy of the secrets, which isn't affected by later
+// changes to the Keyring.
+func (k *Keyring) Snapshot() map[string]AnySecret {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return maps.Clone(k.secrets)
+}
+
+// Load resolves each of the names with p and stores the secrets. It stops at
+// the first error, leaving the secrets resolved before it stored.
+func (k *Keyring) Load(ctx context.Context, p Provider, names ...string) error {
+	for _, name := range names {
+		s, err := p.Get(ctx, name)
+		if err != nil {
+			return err
+		}
+		k.Set(name, s)
+	}
+	return nil
+}
+
+// Rotate adds each of the names to r and stores the secrets, keeping them up
+// to date as r rotates them until they are deleted. It stops at the first
+// error, like Load.
+func (k *Keyring) Rotate(ctx context.Context, r *Rotator, names ...string) error {
+	for _, name := range names {
+		rs, err := r.Add(ctx, name)
+		if err != nil {
+			return err
+		}
+		sub := new(keyringSub)
+		sub.cancel = rs.OnChange(func(_, new Secret[string]) { k.rotated(name, sub, new) })
+		k.mu.Lock()
+		k.unsubscribeLocked(name)
+		if k.subs == nil {
+			k.subs = make(map[string]*keyringSub)
+		}
+		k.subs[name] = sub
+		if k.secrets == nil {
+			k.secrets = make(map[string]AnySecret)
+		}
+		k.secrets[name] = rs.Load()
+		k.mu.Unlock()
+	}
+	return nil
+}
+
+// rotated stores the new secret for name from sub, unless sub has been
+// canceled since, such as by Delete, while the change was being notified.
+func (k *Keyring) rotated(name string, sub *keyringSub, s AnySecret) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.subs[name] == sub {
+		k.secrets[name] = s
+	}
+}
+
+// Provider returns a Provider that resolves secrets from the Keyring,
+// returning ErrNotFound for names it doesn't hold.
+func (k *Keyring) Provider() Provider {
+	return ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		if s, ok := k.Get(name); ok {
+			return s, nil
+		}
+		return Secret[string]{}, notFound(name)
+	})
+}
@@ -0,0 +1,162 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyring(t *testing.T) {
+	var k Keyring
+	if _, ok := k.Get("db"); ok {
+		t.Errorf("expected an empty Keyring")
+	}
+	k.Set("db", Obscure("hunter2"))
+	k.Set("api", Obscure("key"))
+	if s, ok := k.Get("db"); !ok || s.Reveal() != "hunter2" {
+		t.Errorf("Get() = %v, %v; want hunter2", s, ok)
+	}
+	if got := k.Names(); !slices.Equal(got, []string{"api", "db"}) {
+		t.Errorf("Names() = %q", got)
+	}
+
+	snap := k.Snapshot()
+	k.Delete("db")
+	if _, ok := k.Get("db"); ok {
+		t.Errorf("expected the secret to be deleted")
+	}
+	if len(snap) != 2 {
+		t.Errorf("expected the snapshot to be unaffected by Delete")
+	}
+
+	p := k.Provider()
+	if s, err := p.Get(context.Background(), "api"); err != nil || s != Obscure("key") {
+		t.Errorf("Provider().Get() = %v, %v", s, err)
+	}
+	if _, err := p.Get(context.Background(), "db"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestKeyringLoad(t *testing.T) {
+	t.Setenv("CAMO_TEST_DB", "hunter2")
+	var k Keyring
+	p := EnvProvider{Prefix: "CAMO_TEST_"}
+	if err := k.Load(context.Background(), p, "DB"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := k.Get("DB"); s != Obscure("hunter2") {
+		t.Errorf("expected the loaded secret")
+	}
+	if err := k.Load(context.Background(), p, "MISSING"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+}
+
+func TestKeyringRotate(t *testing.T) {
+	var value atomic.Pointer[string]
+	v1, v2 := "v1", "v2"
+	value.Store(&v1)
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Obscure(*value.Load()), nil
+	})
+	r := NewRotator(p, time.Millisecond)
+	defer r.Close()
+
+	var k Keyring
+	if err := k.Rotate(context.Background(), r, "db"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := k.Get("db"); s != Obscure("v1") {
+		t.Errorf("expected the initial secret")
+	}
+	value.Store(&v2)
+	waitFor(t, func() bool {
+		s, _ := k.Get("db")
+		return s == Obscure("v2")
+	})
+}
+
+func TestKeyringRotateDelete(t *testing.T) {
+	var value atomic.Pointer[string]
+	v1, v2 := "v1", "v2"
+	value.Store(&v1)
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Obscure(*value.Load()), nil
+	})
+	r := NewRotator(p, time.Millisecond)
+	defer r.Close()
+
+	var k Keyring
+	if err := k.Rotate(context.Background(), r, "db"); err != nil {
+		t.Fatal(err)
+	}
+	// Rotating the same name again replaces the subscription.
+	if err := k.Rotate(context.Background(), r, "db"); err != nil {
+		t.Fatal(err)
+	}
+	rs, _ := r.Add(context.Background(), "db")
+	subs := func() int {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		return len(rs.subs)
+	}
+	if n := subs(); n != 1 {
+		t.Errorf("got %d subscriptions; want 1", n)
+	}
+
+	k.Delete("db")
+	if n := subs(); n != 0 {
+		t.Errorf("got %d subscriptions after Delete; want 0", n)
+	}
+	value.Store(&v2)
+	waitFor(t, func() bool { return rs.Load() == Obscure("v2") })
+	if _, ok := k.Get("db"); ok {
+		t.Errorf("expected a deleted secret to stay deleted after a rotation")
+	}
+}
+
+func TestKeyringRotateSet(t *testing.T) {
+	var value atomic.Pointer[string]
+	v1, v2 := "v1", "v2"
+	value.Store(&v1)
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Obscure(*value.Load()), nil
+	})
+	r := NewRotator(p, time.Millisecond)
+	defer r.Close()
+
+	var k Keyring
+	if err := k.Rotate(context.Background(), r, "db"); err != nil {
+		t.Fatal(err)
+	}
+	k.Set("db", Obscure("explicit"))
+	rs, _ := r.Add(context.Background(), "db")
+	value.Store(&v2)
+	waitFor(t, func() bool { return rs.Load() == Obscure("v2") })
+	if s, _ := k.Get("db"); s != Obscure("explicit") {
+		t.Errorf("expected a set secret to be kept after a rotation")
+	}
+}
+
+func TestKeyringAnySecret(t *testing.T) {
+	var k Keyring
+	k.Set("tls-key", Obscure([]byte("key")))
+	k.Set("nil", nil)
+	s, ok := k.Lookup("tls-key")
+	if _, isBytes := s.(Secret[[]byte]); !ok || !isBytes {
+		t.Errorf("Lookup() = %T, %v; want a Secret[[]byte]", s, ok)
+	}
+	if s, ok := k.Get("tls-key"); !ok || s != Obscure("key") {
+		t.Errorf("expected Get() to convert the secret to a Secret[string]")
+	}
+	if s, ok := k.Get("nil"); !ok || s.Valid() {
+		t.Errorf("expected a nil secret to be stored as a zero Secret[string]")
+	}
+	if snap := k.Snapshot(); snap["tls-key"] != Obscure([]byte("key")) {
+		t.Errorf("expected the snapshot to keep the type of the secrets")
+	}
+}
@@ -0,0 +1,48 @@
+package camo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// LazySecret is a Secret that is resolved when it is first used, which is
+// useful for secrets that are expensive to resolve, such as those read from a
+// remote secrets manager, and might not be needed at all.
+//
+// It is safe for concurrent use.
+type LazySecret[O Obscurable] struct {
+	resolve func() (Secret[O], error)
+
+	mu sync.Mutex
+	s  atomic.Pointer[Secret[O]]
+}
+
+// Lazy returns a LazySecret that is resolved by calling resolve.
+func Lazy[O Obscurable](resolve func() (Secret[O], error)) *LazySecret[O] {
+	return &LazySecret[O]{resolve: resolve}
+}
+
+// Get returns the Secret, resolving it if this is the first call. Concurrent
+// first calls share a single call to the resolve function. Errors are not
+// cached, so if resolving fails, the next call to Get tries again.
+func (l *LazySecret[O]) Get() (Secret[O], error) {
+	if s := l.s.Load(); s != nil {
+		return *s, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if s := l.s.Load(); s != nil {
+		return *s, nil
+	}
+	s, err := l.resolve()
+	if err != nil {
+		return Secret[O]{}, err
+	}
+	l.s.Store(&s)
+	return s, nil
+}
+
+// Resolved reports if the Secret has been resolved.
+func (l *LazySecret[O]) Resolved() bool {
+	return l.s.Load() != nil
+}
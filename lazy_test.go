@@ -0,0 +1,47 @@
+package camo
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	l := Lazy(func() (Secret[string], error) {
+		if calls.Add(1) == 1 {
+			return Secret[string]{}, errors.New("unavailable")
+		}
+		return Obscure("hunter2"), nil
+	})
+	if l.Resolved() || calls.Load() != 0 {
+		t.Fatalf("expected the secret not to be resolved before use")
+	}
+	if _, err := l.Get(); err == nil {
+		t.Fatalf("expected the first error to be returned")
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := l.Get()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got := s.Reveal(); got != "hunter2" {
+				t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 2 {
+		t.Errorf("resolve called %d times; want 2", n)
+	}
+	if !l.Resolved() {
+		t.Errorf("expected the secret to be resolved")
+	}
+}
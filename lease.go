@@ -0,0 +1,194 @@
+package camo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Lease tracks the validity of a credential that can be revoked upstream,
+// such as a dynamic database credential issued under a Vault lease. Revoking
+// the Lease invalidates the SecretVars holding the credential (see
+// SecretVar.StoreLeased), so that it isn't used any further.
+//
+// A Lease that expires can be revoked with time.AfterFunc.
+//
+// It is safe for concurrent use.
+type Lease struct {
+	id string
+
+	mu       sync.Mutex
+	done     chan struct{}
+	revoked  bool
+	nextID   int
+	onRevoke map[int]func()
+}
+
+// NewLease returns a Lease with the given ID, such as the ID of the upstream
+// lease.
+func NewLease(id string) *Lease {
+	return &Lease{
+		id:       id,
+		done:     make(chan struct{}),
+		onRevoke: make(map[int]func()),
+	}
+}
+
+// ID returns the ID of the Lease.
+func (l *Lease) ID() string {
+	return l.id
+}
+
+// Revoke revokes the Lease, calling the functions registered with OnRevoke
+// in the order they were registered. Revoking a Lease again has no effect.
+func (l *Lease) Revoke() {
+	l.mu.Lock()
+	if l.revoked {
+		l.mu.Unlock()
+		return
+	}
+	l.revoked = true
+	close(l.done)
+	fs := make([]func(), 0, len(l.onRevoke))
+	for id := range l.nextID {
+		if f, ok := l.onRevoke[id]; ok {
+			fs = append(fs, f)
+		}
+	}
+	l.onRevoke = nil
+	l.mu.Unlock()
+	for _, f := range fs {
+		f()
+	}
+}
+
+// Revoked reports if the Lease has been revoked.
+func (l *Lease) Revoked() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.revoked
+}
+
+// Done returns a channel that is closed when the Lease is revoked.
+func (l *Lease) Done() <-chan struct{} {
+	return l.done
+}
+
+// OnRevoke registers f to be called when the Lease is revoked, and returns a
+// function that unregisters it. If the Lease has already been revoked, f is
+// called immediately.
+func (l *Lease) OnRevoke(f func()) (cancel func()) {
+	l.mu.Lock()
+	if l.revoked {
+		l.mu.Unlock()
+		f()
+		return func() {}
+	}
+	id := l.nextID
+	l.nextID++
+	l.onRevoke[id] = f
+	l.mu.Unlock()
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.onRevoke, id)
+	}
+}
+
+// StoreLeased replaces the current Secret with s, which is replaced with the
+// zero Secret when l is revoked, unless it has been replaced by then.
+func (v *SecretVar[O]) StoreLeased(s Secret[O], l *Lease) {
+	p := &s
+	v.p.Store(p)
+	l.OnRevoke(func() { v.p.CompareAndSwap(p, nil) })
+}
+
+// KeepLeased stores a Secret acquired with acquire in v, and acquires a new
+// one whenever the Lease of the current one is revoked, until ctx is done. It
+// returns an error if the initial acquisition fails.
+//
+// Until a new Secret is acquired, v holds the zero Secret. Failures to
+// acquire a new Secret are reported to onError if it isn't nil, and retried
+// with exponential backoff from one second up to a minute. A Lease that is
+// revoked less than a second after it was acquired, such as one that had
+// already expired, is treated as a failure, so that a misbehaving backend
+// isn't hammered with acquisitions.
+func KeepLeased[O Obscurable](ctx context.Context, v *SecretVar[O], acquire func(context.Context) (Secret[O], *Lease, error), onError func(error)) error {
+	s, l, err := acquire(ctx)
+	if err != nil {
+		return err
+	}
+	k := &leaseKeeper[O]{
+		ctx:      ctx,
+		v:        v,
+		acquire:  acquire,
+		onError:  onError,
+		minRetry: minLeaseRetry,
+		maxRetry: maxLeaseRetry,
+	}
+	k.keep(s, l, 0)
+	return nil
+}
+
+// The bounds of the backoff of KeepLeased, which are variables so that tests
+// can shorten them.
+var (
+	minLeaseRetry = time.Second
+	maxLeaseRetry = time.Minute
+)
+
+// leaseKeeper keeps a SecretVar up to date for KeepLeased.
+type leaseKeeper[O Obscurable] struct {
+	ctx      context.Context
+	v        *SecretVar[O]
+	acquire  func(context.Context) (Secret[O], *Lease, error)
+	onError  func(error)
+	minRetry time.Duration
+	maxRetry time.Duration
+}
+
+// keep stores s in v, and acquires a new Secret when l is revoked. The
+// acquisition of s was preceded by a delay of backoff.
+func (k *leaseKeeper[O]) keep(s Secret[O], l *Lease, backoff time.Duration) {
+	acquired := time.Now()
+	k.v.StoreLeased(s, l)
+	l.OnRevoke(func() {
+		var wait time.Duration
+		if time.Since(acquired) < k.minRetry {
+			wait = k.next(backoff)
+		}
+		go k.reacquire(wait)
+	})
+}
+
+// next returns the delay to wait after a failed acquisition that was itself
+// preceded by a delay of d.
+func (k *leaseKeeper[O]) next(d time.Duration) time.Duration {
+	return min(max(2*d, k.minRetry), k.maxRetry)
+}
+
+func (k *leaseKeeper[O]) reacquire(wait time.Duration) {
+	for {
+		if wait > 0 {
+			select {
+			case <-k.ctx.Done():
+			case <-time.After(wait):
+			}
+		}
+		if k.ctx.Err() != nil {
+			return
+		}
+		s, l, err := k.acquire(k.ctx)
+		if err == nil {
+			k.keep(s, l, wait)
+			return
+		}
+		if k.ctx.Err() != nil {
+			return
+		}
+		if k.onError != nil {
+			k.onError(err)
+		}
+		wait = k.next(wait)
+	}
+}
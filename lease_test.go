@@ -0,0 +1,133 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLease(t *testing.T) {
+	l := NewLease("database/creds/app/1")
+	var calls []int
+	l.OnRevoke(func() { calls = append(calls, 1) })
+	cancel := l.OnRevoke(func() { calls = append(calls, 2) })
+	l.OnRevoke(func() { calls = append(calls, 3) })
+	cancel()
+
+	l.Revoke()
+	l.Revoke()
+	if !l.Revoked() {
+		t.Errorf("expected the lease to be revoked")
+	}
+	select {
+	case <-l.Done():
+	default:
+		t.Errorf("expected Done to be closed")
+	}
+	l.OnRevoke(func() { calls = append(calls, 4) })
+	if fmt.Sprint(calls) != "[1 3 4]" {
+		t.Errorf("callbacks called as %v; want [1 3 4]", calls)
+	}
+}
+
+func TestSecretVarStoreLeased(t *testing.T) {
+	var v SecretVar[string]
+	l1 := NewLease("1")
+	v.StoreLeased(Obscure("v1"), l1)
+	l1.Revoke()
+	if v.Load().Valid() {
+		t.Errorf("expected a revoked secret to be invalidated")
+	}
+
+	l2 := NewLease("2")
+	v.StoreLeased(Obscure("v2"), l2)
+	v.Store(Obscure("v3"))
+	l2.Revoke()
+	if got := v.Load(); got != Obscure("v3") {
+		t.Errorf("expected revoking a replaced secret to have no effect")
+	}
+}
+
+// shortenLeaseRetry shortens the backoff of KeepLeased for the test.
+func shortenLeaseRetry(t *testing.T) {
+	minRetry, maxRetry := minLeaseRetry, maxLeaseRetry
+	minLeaseRetry, maxLeaseRetry = 10*time.Millisecond, 40*time.Millisecond
+	t.Cleanup(func() { minLeaseRetry, maxLeaseRetry = minRetry, maxRetry })
+}
+
+func TestKeepLeased(t *testing.T) {
+	shortenLeaseRetry(t)
+	var (
+		mu     sync.Mutex
+		n      int
+		leases []*Lease
+	)
+	errs := make(chan error, 1)
+	acquire := func(ctx context.Context) (Secret[string], *Lease, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		n++
+		if n == 2 {
+			return Secret[string]{}, nil, errors.New("unavailable")
+		}
+		l := NewLease(fmt.Sprint(n))
+		leases = append(leases, l)
+		return Obscure(fmt.Sprintf("user%d", n)), l, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var v SecretVar[string]
+	if err := KeepLeased(ctx, &v, acquire, func(err error) { errs <- err }); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Load().Reveal(); got != "user1" {
+		t.Fatalf("Load() = %q; want %q", got, "user1")
+	}
+	mu.Lock()
+	l := leases[0]
+	mu.Unlock()
+	l.Revoke()
+	if err := <-errs; err == nil {
+		t.Errorf("expected the failed acquisition to be reported")
+	}
+	waitFor(t, func() bool { return v.Load() == Obscure("user3") })
+}
+
+func TestKeepLeasedBackoff(t *testing.T) {
+	shortenLeaseRetry(t)
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	acquire := func(ctx context.Context) (Secret[string], *Lease, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, time.Now())
+		// The lease has already expired by the time it is returned.
+		l := NewLease("expired")
+		l.Revoke()
+		return Obscure("user"), l, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var v SecretVar[string]
+	if err := KeepLeased(ctx, &v, acquire, nil); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	mu.Lock()
+	defer mu.Unlock()
+	// The delays are 10, 20, 40, 40, ... milliseconds.
+	if n := len(times); n < 3 || n > 7 {
+		t.Fatalf("got %d acquisitions in 200ms; want between 3 and 7", n)
+	}
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d < minLeaseRetry {
+			t.Errorf("acquisition %d came %v after the previous one; want at least %v", i, d, minLeaseRetry)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package camo
+
+import (
+	"bytes"
+	"runtime"
+	"unsafe"
+
+	"github.com/rbranson/camo/internal/mem"
+)
+
+// ObscureLocked is like Obscure, but places the content in dedicated
+// page-aligned memory that is locked into RAM with mlock (or VirtualLock on
+// Windows), so it is never written to swap. The memory is made read-only
+// while in use, and is wiped and released once the Secret is no longer
+// reachable.
+//
+// Locking can fail, most commonly because the process has reached its
+// RLIMIT_MEMLOCK, in which case ObscureLocked silently falls back to the
+// behavior of Obscure. Use Locked to find out if the content was locked.
+//
+// Pass ExcludeFromDumps to also keep the content out of core dumps.
+func ObscureLocked[O Obscurable](content O, opts ...Option) Secret[O] {
+	o := makeOptions(opts)
+	src := contentView(content)
+	if o.normalized && !o.form.IsNormal(src) {
+		src = normalize(bytes.Clone(src), o.form)
+		defer wipe(src)
+	}
+	buf, err := mem.Map(mem.RoundUp(len(src)))
+	if err != nil {
+		return Obscure(content, opts...)
+	}
+	if err := mem.Lock(buf); err != nil {
+		mem.Unmap(buf)
+		return Obscure(content, opts...)
+	}
+	if o.noDump {
+		// This is best effort, as it only fails on kernels that predate
+		// the advice, where there is nothing better to be done.
+		_ = mem.ExcludeFromDumps(buf)
+	}
+	n := copy(buf, src)
+	if o.trimmed {
+		n = len(trimTrailingSpace(buf[:n]))
+	}
+	if err := mem.Protect(buf, mem.ReadOnly); err != nil {
+		freeLocked(buf)
+		return Obscure(content, opts...)
+	}
+	b := &box{
+		content: unsafe.String(unsafe.SliceData(buf), n),
+		locked:  true,
+		policy:  o.policy,
+	}
+	runtime.AddCleanup(b, freeLocked, buf)
+	return newSecret[O](b)
+}
+
+func freeLocked(buf []byte) {
+	// If the memory can't be made writable again, unmapping it is the best
+	// that can be done, as the kernel zeroes pages before reusing them.
+	if mem.Protect(buf, mem.ReadWrite) == nil {
+		clear(buf)
+	}
+	mem.Unlock(buf)
+	mem.Unmap(buf)
+}
+
+// Locked reports if the content of the secret is held in memory that is
+// locked into RAM, as done by ObscureLocked.
+func (s Secret[O]) Locked() bool {
+	return s.Valid() && s.box().locked
+}
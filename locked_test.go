@@ -0,0 +1,101 @@
+package camo
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/rbranson/camo/internal/mem"
+)
+
+func canLock() bool {
+	buf, err := mem.Map(mem.RoundUp(1))
+	if err != nil {
+		return false
+	}
+	defer mem.Unmap(buf)
+	if err := mem.Lock(buf); err != nil {
+		return false
+	}
+	mem.Unlock(buf)
+	return true
+}
+
+func TestObscureLocked(t *testing.T) {
+	s := ObscureLocked("hunter2")
+	if !s.Valid() {
+		t.Fatalf("expected locked secret to be valid")
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if s != Obscure("hunter2") {
+		t.Errorf("expected locked secret to equal an unlocked one with the same content")
+	}
+	if Obscure("hunter2").Locked() {
+		t.Errorf("expected Obscure to not lock the content")
+	}
+	if (Secret[string]{}).Locked() {
+		t.Errorf("expected zero secret to not be locked")
+	}
+}
+
+func TestObscureLockedTrimmed(t *testing.T) {
+	if got := ObscureLocked("hunter2 \n", Trimmed()).Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestObscureLockedNormalized(t *testing.T) {
+	if got := ObscureLocked([]byte("pa\u0308ss"), NormalizedNFC()).Reveal(); string(got) != "p\u00e4ss" {
+		t.Errorf("Reveal() = %q; want %q", got, "p\u00e4ss")
+	}
+}
+
+func TestObscureLockedIsLocked(t *testing.T) {
+	if !canLock() {
+		t.Skip("unable to lock memory")
+	}
+	if !ObscureLocked("hunter2").Locked() {
+		t.Errorf("expected the content to be locked")
+	}
+}
+
+func TestObscureLockedBytes(t *testing.T) {
+	content := []byte("hunter2")
+	s := ObscureLocked(content)
+	content[0] = 'H'
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if got := s.AppendTo(nil); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("AppendTo() = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestObscureLockedEmpty(t *testing.T) {
+	s := ObscureLocked("")
+	if !s.Valid() {
+		t.Fatalf("expected empty locked secret to be valid")
+	}
+	if got := s.Reveal(); got != "" {
+		t.Errorf("Reveal() = %q; want empty", got)
+	}
+}
+
+func TestObscureLockedRevealOutlivesSecret(t *testing.T) {
+	revealed := ObscureLocked("hunter2").Reveal()
+	for range 3 {
+		runtime.GC()
+	}
+	if revealed != "hunter2" {
+		t.Errorf("revealed = %q; want %q", revealed, "hunter2")
+	}
+}
+
+func TestObscureLockedExcludeFromDumps(t *testing.T) {
+	s := ObscureLocked([]byte("hunter2"), ExcludeFromDumps())
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+}
@@ -0,0 +1,39 @@
+package camo
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// Map returns a Secret holding the result of f applied to the content of s,
+// such as for normalizing, stripping a prefix from, or re-encoding a secret.
+// f is given a temporary copy of the content, which is wiped once the result
+// has been obscured. The result keeps the label and policies of s, such as
+// AllowCallers. If O is []byte, the result is wiped as well, so f must
+// return a slice that isn't used elsewhere, but it may alias its argument.
+// f must not retain its argument or the result. It panics if the secret is
+// zero.
+func Map[O Obscurable](s Secret[O], f func(O) O) Secret[O] {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("Map")
+	}
+	s.revealed("Map")
+	tmp := []byte(s.str())
+	runtime.KeepAlive(s)
+	defer wipe(tmp)
+
+	var arg O
+	switch p := any(&arg).(type) {
+	case *string:
+		*p = unsafe.String(unsafe.SliceData(tmp), len(tmp))
+	case *[]byte:
+		*p = tmp
+	}
+	result := f(arg)
+	out := Obscure(result, withPolicy(s.box().policy))
+	if b, ok := any(result).([]byte); ok {
+		wipe(b)
+	}
+	return out
+}
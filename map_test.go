@@ -0,0 +1,56 @@
+package camo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	got := Map(Obscure("Bearer hunter2"), func(s string) string {
+		return strings.TrimPrefix(s, "Bearer ")
+	})
+	if got != Obscure("hunter2") {
+		t.Errorf("got = %q; want %q", got.Reveal(), "hunter2")
+	}
+
+	var arg, result []byte
+	got2 := Map(Obscure([]byte(" hunter2\n")), func(b []byte) []byte {
+		arg = b
+		result = bytes.ToUpper(bytes.TrimSpace(b))
+		return result
+	})
+	if string(got2.Reveal()) != "HUNTER2" {
+		t.Errorf("got = %q; want %q", got2.Reveal(), "HUNTER2")
+	}
+	if !bytes.Equal(arg, make([]byte, len(arg))) || !bytes.Equal(result, make([]byte, len(result))) {
+		t.Errorf("expected the intermediates to be wiped, got %q and %q", arg, result)
+	}
+
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { Map(zero, strings.ToUpper) }); !ok {
+		t.Errorf("expected Map() of a zero secret to panic")
+	}
+}
+
+func TestMapKeepsPolicy(t *testing.T) {
+	var violations []PolicyViolation
+	SetPolicyViolationHandler(func(v PolicyViolation) { violations = append(violations, v) })
+	defer SetPolicyViolationHandler(nil)
+
+	var accesses int
+	key := Obscure([]byte("0123456789abcdef"))
+	canary := Canary("hunter2", func(CanaryEvent) { accesses++ }, Labeled("db"), AllowCallers("example.com/app"), SipHashed(key))
+	got := Map(canary, strings.ToUpper)
+	if got.Label() != "db" || !got.IsCanary() {
+		t.Errorf("got label %q and IsCanary() = %v; want the ones of the source", got.Label(), got.IsCanary())
+	}
+	if got != Obscure("HUNTER2", SipHashed(key)) {
+		t.Errorf("expected the hash option to be kept")
+	}
+	violations, accesses = nil, 0
+	got.Reveal()
+	if len(violations) != 1 || accesses != 1 {
+		t.Errorf("got %d violations and %d accesses; want 1 and 1", len(violations), accesses)
+	}
+}
@@ -0,0 +1,68 @@
+package camo
+
+import (
+	"runtime"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MaskOptions configures Masked. The zero value shows none of the content.
+type MaskOptions struct {
+	// Head and Tail are the number of characters shown at the start and at
+	// the end of the content.
+	Head, Tail int
+
+	// MinLen is the length in bytes below which none of the content is
+	// shown, as a short secret would be mostly given away by its ends.
+	// Regardless of it, at least half of the characters of the content are
+	// always hidden.
+	MinLen int
+}
+
+// Masked returns a partially masked preview of the secret for operator-facing
+// debug output, where total redaction makes troubleshooting impossible, such
+// as "sk_l…9f2 (len 51)" for a head of 4 characters and a tail of 3. The
+// length is in bytes. It reveals part of the content and its length, so it
+// should not be used for secrets that are short or weak. It panics if the
+// secret is zero.
+func (s Secret[O]) Masked(opts MaskOptions) string {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("Masked")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("Masked")
+	content := s.str()
+	head, tail := max(opts.Head, 0), max(opts.Tail, 0)
+	if len(content) < opts.MinLen || 2*(head+tail) > utf8.RuneCountInString(content) {
+		head, tail = 0, 0
+	}
+	buf := make([]byte, 0, 32)
+	buf = append(buf, content[:runesPrefix(content, head)]...)
+	buf = append(buf, "…"...)
+	buf = append(buf, content[runesSuffix(content, tail):]...)
+	buf = append(buf, " (len "...)
+	buf = strconv.AppendInt(buf, int64(len(content)), 10)
+	buf = append(buf, ')')
+	return string(buf)
+}
+
+// runesPrefix returns the length in bytes of the first n runes of s.
+func runesPrefix(s string, n int) int {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return i
+}
+
+// runesSuffix returns the offset in bytes of the last n runes of s.
+func runesSuffix(s string, n int) int {
+	i := len(s)
+	for ; n > 0 && i > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+	}
+	return i
+}
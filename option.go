@@ -0,0 +1,105 @@
+package camo
+
+import "golang.org/x/text/unicode/norm"
+
+// Option configures how a Secret is created.
+type Option func(*options)
+
+type options struct {
+	guarded       bool
+	noDump        bool
+	trimmed       bool
+	normalized    bool
+	form          norm.Form
+	allowInsecure bool
+	policy
+}
+
+func makeOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Guarded places the content of the secret outside of the Go heap, in
+// memory surrounded by inaccessible guard pages, so the garbage collector
+// never copies it around and buffer overruns into it fault immediately. A
+// canary in front of the content detects smaller underruns, and the program
+// crashes if it is found to be corrupted when the memory is released. The
+// memory is read-only while in use, is locked into RAM where possible (see
+// Secret.Locked), and is wiped and released once the Secret is no longer
+// reachable.
+//
+// Each guarded secret uses at least three pages of memory. If the memory
+// can't be allocated, the option is ignored.
+func Guarded() Option {
+	return func(o *options) {
+		o.guarded = true
+	}
+}
+
+// ExcludeFromDumps excludes the memory holding the content of a locked or
+// guarded secret from core dumps, using madvise(MADV_DONTDUMP) on Linux and
+// madvise(MADV_NOCORE) on FreeBSD and DragonFly. It is ignored on other
+// platforms, and for secrets whose content is on the Go heap.
+func ExcludeFromDumps() Option {
+	return func(o *options) {
+		o.noDump = true
+	}
+}
+
+// Trimmed strips trailing white space, including newlines, from the content
+// of the secret, such as the newline that files and environment variables
+// often end with, which otherwise breaks authentication in confusing ways.
+func Trimmed() Option {
+	return func(o *options) {
+		o.trimmed = true
+	}
+}
+
+// NormalizedNFC applies Unicode normalization form C to the content of the
+// secret, so that passwords compare and derive keys consistently regardless
+// of how the terminal or browser they were typed into composed them. Content
+// that isn't valid UTF-8 is left as it is.
+func NormalizedNFC() Option {
+	return func(o *options) {
+		o.normalized, o.form = true, norm.NFC
+	}
+}
+
+// NormalizedNFKC is like NormalizedNFC, but applies Unicode normalization
+// form KC, which also folds compatibility characters, such as full-width
+// letters, as recommended for passwords by RFC 8265.
+func NormalizedNFKC() Option {
+	return func(o *options) {
+		o.normalized, o.form = true, norm.NFKC
+	}
+}
+
+// AllowInsecurePermissions lets FromFile read files that are readable by
+// their group or by others, such as Kubernetes secret volumes mounted with
+// the default mode of 0644.
+func AllowInsecurePermissions() Option {
+	return func(o *options) {
+		o.allowInsecure = true
+	}
+}
+
+// withPolicy gives the secret the label and policies p, such as those of the
+// secret its content is derived from.
+func withPolicy(p policy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// Labeled attaches a label to the secret, such as the name it was resolved
+// with, which is passed to the reveal hook (see SetRevealHook). The label is
+// not secret, and doesn't affect comparisons.
+func Labeled(label string) Option {
+	return func(o *options) {
+		o.label = label
+	}
+}
@@ -0,0 +1,171 @@
+package camo
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Argon2idParams are the parameters of Argon2id password hashing.
+type Argon2idParams struct {
+	// Time is the number of passes over memory.
+	Time uint32
+
+	// Memory is the amount of memory used, in KiB.
+	Memory uint32
+
+	// Threads is the degree of parallelism.
+	Threads uint8
+
+	// SaltLen is the length of the random salt, in bytes.
+	SaltLen uint32
+
+	// KeyLen is the length of the hash, in bytes.
+	KeyLen uint32
+}
+
+// DefaultArgon2idParams are the second recommended parameters of RFC 9106,
+// for environments where 2 GiB of memory per hash is too much.
+var DefaultArgon2idParams = Argon2idParams{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+var (
+	errInvalidArgon2idHash   = errors.New("camo: invalid argon2id hash")
+	errInvalidArgon2idParams = errors.New("camo: invalid argon2id parameters")
+)
+
+// The limits on the parameters of the hashes given to VerifyArgon2id, which
+// may come from untrusted storage, keep a crafted hash from exhausting the
+// memory of the process. The memory limit is that of the first recommended
+// parameters of RFC 9106.
+const (
+	maxArgon2idMemory = 2 * 1024 * 1024
+	maxArgon2idKeyLen = 1024
+)
+
+// valid reports if p is within the limits, which argon2.IDKey requires of
+// the time and the parallelism to not panic.
+func (p Argon2idParams) valid() bool {
+	return p.Time >= 1 && p.Threads >= 1 &&
+		p.Memory >= 8*uint32(p.Threads) && p.Memory <= maxArgon2idMemory &&
+		p.KeyLen >= 1 && p.KeyLen <= maxArgon2idKeyLen
+}
+
+// HashArgon2id hashes the secret as a password with Argon2id and a random
+// salt, returning the hash in the PHC string format, such as
+// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>". It returns an error if the
+// parameters are outside of the limits of VerifyArgon2id, or Time, Threads,
+// or KeyLen is zero. It panics if the secret is zero.
+func (s Secret[O]) HashArgon2id(p Argon2idParams) (string, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("HashArgon2id")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("HashArgon2id")
+	if !p.valid() {
+		return "", errInvalidArgon2idParams
+	}
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(s.view(), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyArgon2id reports, in constant time, if the secret is the password
+// that produced encoded, which must be in the format returned by
+// HashArgon2id. Hashes that use more than 2 GiB of memory or are longer than
+// 1024 bytes are rejected as invalid. It panics if the secret is zero.
+func (s Secret[O]) VerifyArgon2id(encoded string) (bool, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("VerifyArgon2id")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("VerifyArgon2id")
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return false, errInvalidArgon2idHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, errInvalidArgon2idHash
+	}
+	if version != argon2.Version {
+		return false, fmt.Errorf("camo: unsupported argon2id version %d", version)
+	}
+	var p Argon2idParams
+	var threads uint32
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &threads); err != nil {
+		return false, errInvalidArgon2idHash
+	}
+	if threads > 255 {
+		return false, errInvalidArgon2idHash
+	}
+	p.Threads = uint8(threads)
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, errInvalidArgon2idHash
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) > maxArgon2idKeyLen {
+		return false, errInvalidArgon2idHash
+	}
+	p.KeyLen = uint32(len(want))
+	if !p.valid() {
+		return false, errInvalidArgon2idHash
+	}
+	got := argon2.IDKey(s.view(), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
+
+// HashBcrypt hashes the secret as a password with bcrypt at the given cost.
+// It panics if the secret is zero.
+func (s Secret[O]) HashBcrypt(cost int) (string, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("HashBcrypt")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("HashBcrypt")
+	hash, err := bcrypt.GenerateFromPassword(s.view(), cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// VerifyBcrypt reports, in constant time, if the secret is the password that
+// produced the bcrypt hash. It panics if the secret is zero.
+func (s Secret[O]) VerifyBcrypt(hash string) (bool, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("VerifyBcrypt")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("VerifyBcrypt")
+	err := bcrypt.CompareHashAndPassword([]byte(hash), s.view())
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
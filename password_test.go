@@ -0,0 +1,112 @@
+package camo
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2idParams keeps the tests fast.
+var testArgon2idParams = Argon2idParams{Time: 1, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func TestArgon2id(t *testing.T) {
+	pw := Obscure("correct horse")
+	encoded, err := pw.HashArgon2id(testArgon2idParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("unexpected encoding: %q", encoded)
+	}
+
+	ok, err := pw.VerifyArgon2id(encoded)
+	if err != nil || !ok {
+		t.Errorf("got = %v, %v; want true, nil", ok, err)
+	}
+	ok, err = Obscure([]byte("battery staple")).VerifyArgon2id(encoded)
+	if err != nil || ok {
+		t.Errorf("got = %v, %v; want false, nil", ok, err)
+	}
+
+	other, err := pw.HashArgon2id(testArgon2idParams)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == encoded {
+		t.Errorf("expected hashes to use different salts")
+	}
+}
+
+func TestVerifyArgon2idInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"$argon2i$v=19$m=64,t=1,p=1$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=16$m=64,t=1,p=1$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=x,t=1,p=1$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ$",
+		"$argon2id$v=19$m=64,t=0,p=1$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=0$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=4096,t=1,p=256$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=15,t=1,p=2$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c29tZXNhbHQ$aGFzaA",
+		"$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQ$" + strings.Repeat("A", 1368),
+	}
+	for _, encoded := range cases {
+		if _, err := Obscure("password").VerifyArgon2id(encoded); err == nil {
+			t.Errorf("expected error for %q", encoded)
+		}
+	}
+}
+
+func TestHashArgon2idInvalidParams(t *testing.T) {
+	cases := []Argon2idParams{
+		{},
+		{Time: 0, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64, Threads: 0, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 0},
+		{Time: 1, Memory: 15, Threads: 2, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: maxArgon2idMemory + 1, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: maxArgon2idKeyLen + 1},
+	}
+	for _, p := range cases {
+		if _, err := Obscure("password").HashArgon2id(p); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
+
+func TestBcrypt(t *testing.T) {
+	pw := Obscure("correct horse")
+	hash, err := pw.HashBcrypt(bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ok, err := pw.VerifyBcrypt(hash)
+	if err != nil || !ok {
+		t.Errorf("got = %v, %v; want true, nil", ok, err)
+	}
+	ok, err = Obscure("battery staple").VerifyBcrypt(hash)
+	if err != nil || ok {
+		t.Errorf("got = %v, %v; want false, nil", ok, err)
+	}
+	if _, err := pw.VerifyBcrypt("not a hash"); err == nil {
+		t.Errorf("expected error for invalid hash")
+	}
+}
+
+func TestPanicOnZeroPasswordHashing(t *testing.T) {
+	var zero Secret[string]
+	funcs := map[string]func(){
+		"HashArgon2id":   func() { zero.HashArgon2id(testArgon2idParams) },
+		"VerifyArgon2id": func() { zero.VerifyArgon2id("") },
+		"HashBcrypt":     func() { zero.HashBcrypt(bcrypt.MinCost) },
+		"VerifyBcrypt":   func() { zero.VerifyBcrypt("") },
+	}
+	for name, f := range funcs {
+		if _, ok := capturePanic(f); !ok {
+			t.Errorf("expected zero.%s() to panic", name)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package camo
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// packagePath is the import path of this package.
+const packagePath = "github.com/rbranson/camo"
+
+// AllowCallers restricts the packages that may extract the content of the
+// secret, such as with Reveal or AppendTo, to those with the given import
+// paths. A path ending in "/..." also matches the packages below it, like
+// the patterns used by the go command.
+//
+// The caller is the first function on the stack outside of this package, so
+// the restriction also applies through wrappers such as RateLimit. It covers
+// every method that uses the content, including as a key, as with HMAC or
+// SealAESGCM, and is kept by the secrets derived from the content, such as
+// with Map, Split, Concat, Seal or Unseal, but not by keys derived with
+// DeriveHKDF or DerivePBKDF2. Nothing stops an allowed package from passing
+// on the content once it has extracted it.
+//
+// Violations are reported to the policy violation handler (see
+// SetPolicyViolationHandler). Inspecting the stack has a cost, so the option
+// should be used with care in performance-sensitive programs.
+func AllowCallers(paths ...string) Option {
+	return func(o *options) {
+		o.allowed = append(slices.Clip(o.allowed), paths...)
+	}
+}
+
+// PolicyViolation describes an attempt to extract the content of a secret
+// from a package that isn't allowed to by AllowCallers.
+type PolicyViolation struct {
+	RevealInfo
+
+	// Package is the import path of the caller's package.
+	Package string
+}
+
+// Error implements error.
+func (v PolicyViolation) Error() string {
+	return fmt.Sprintf("camo: %s of secret %q by disallowed package %s at %s:%d", v.Op, v.Label, v.Package, v.File, v.Line)
+}
+
+var violationHandler atomic.Pointer[func(PolicyViolation)]
+
+// SetPolicyViolationHandler sets a process-wide handler that is called when
+// a package that isn't allowed to by AllowCallers extracts the content of a
+// secret. The extraction goes ahead once the handler returns, so a handler
+// that should prevent it must panic. A nil handler restores the default,
+// which panics with the PolicyViolation.
+func SetPolicyViolationHandler(handler func(PolicyViolation)) {
+	if handler == nil {
+		violationHandler.Store(nil)
+		return
+	}
+	violationHandler.Store(&handler)
+}
+
+// checkCaller reports a violation if the caller isn't allowed by b. It must
+// be called directly by revealed.
+func checkCaller(op string, b *box) {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, checkCaller, and box.revealed.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		pkg := funcPackage(f.Function)
+		if pkg != packagePath {
+			if !allowedCaller(b.allowed, pkg) {
+				reportViolation(PolicyViolation{
+					RevealInfo: RevealInfo{
+						Op:       op,
+						Label:    b.label,
+						Function: f.Function,
+						File:     f.File,
+						Line:     f.Line,
+					},
+					Package: pkg,
+				})
+			}
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+func allowedCaller(allowed []string, pkg string) bool {
+	for _, path := range allowed {
+		if prefix, ok := strings.CutSuffix(path, "/..."); ok {
+			if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+				return true
+			}
+		} else if pkg == path {
+			return true
+		}
+	}
+	return false
+}
+
+func reportViolation(v PolicyViolation) {
+	if h := violationHandler.Load(); h != nil {
+		(*h)(v)
+		return
+	}
+	panic(v)
+}
+
+// funcPackage returns the import path of the package of a function with the
+// given fully qualified name, such as "example.com/pkg.(*T).Method".
+func funcPackage(name string) string {
+	// Type parameters may contain slashes and dots, and don't affect the
+	// package.
+	end := len(name)
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		end = i
+	}
+	slash := strings.LastIndexByte(name[:end], '/') + 1
+	if dot := strings.IndexByte(name[slash:end], '.'); dot >= 0 {
+		end = slash + dot
+	}
+	// Dots in the last element of the path are escaped by the linker.
+	return strings.ReplaceAll(name[:end], "%2e", ".")
+}
@@ -0,0 +1,71 @@
+package camo
+
+import (
+	"testing"
+)
+
+func TestAllowCallers(t *testing.T) {
+	var violations []PolicyViolation
+	SetPolicyViolationHandler(func(v PolicyViolation) { violations = append(violations, v) })
+	defer SetPolicyViolationHandler(nil)
+
+	// Functions in this package are skipped when finding the caller, so
+	// the caller of the tests is the testing package.
+	Obscure("hunter2", AllowCallers("testing")).Reveal()
+	RateLimit(Obscure("hunter2", AllowCallers("example.com/app/...", "testing")), 1, 1).Reveal()
+	if len(violations) != 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+
+	s := Obscure([]byte("hunter2"), AllowCallers("example.com/app/..."), Labeled("db"))
+	if got := s.AppendTo(nil); string(got) != "hunter2" {
+		t.Errorf("AppendTo() = %q; want the content once the handler returns", got)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("got %d violations; want 1", len(violations))
+	}
+	v := violations[0]
+	if v.Package != "testing" || v.Op != "AppendTo" || v.Label != "db" || v.File == "" {
+		t.Errorf("violation = %+v", v)
+	}
+}
+
+func TestAllowCallersDefaultHandler(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(PolicyViolation); !ok {
+			t.Errorf("expected a panic with a PolicyViolation")
+		}
+	}()
+	Obscure("hunter2", AllowCallers("example.com/app")).Reveal()
+}
+
+func TestFuncPackage(t *testing.T) {
+	for name, want := range map[string]string{
+		"main.main":                                   "main",
+		"example.com/app.Run":                         "example.com/app",
+		"example.com/app/db.(*Pool).Open":             "example.com/app/db",
+		"example.com/app/db.Open.func1":               "example.com/app/db",
+		"github.com/rbranson/camo.Secret[...].Reveal": "github.com/rbranson/camo",
+		"example.com/x.F[go.shape.*example.com/y.T]":  "example.com/x",
+		"gopkg.in/yaml%2ev3.Unmarshal":                "gopkg.in/yaml.v3",
+	} {
+		if got := funcPackage(name); got != want {
+			t.Errorf("funcPackage(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
+
+func TestAllowedCaller(t *testing.T) {
+	allowed := []string{"example.com/app/...", "example.com/lib"}
+	for pkg, want := range map[string]bool{
+		"example.com/app":       true,
+		"example.com/app/db":    true,
+		"example.com/appx":      false,
+		"example.com/lib":       true,
+		"example.com/lib/inner": false,
+	} {
+		if got := allowedCaller(allowed, pkg); got != want {
+			t.Errorf("allowedCaller(%q) = %v; want %v", pkg, got, want)
+		}
+	}
+}
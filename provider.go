@@ -0,0 +1,138 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"unsafe"
+)
+
+// ErrNotFound is returned by a Provider when it has no secret with the given
+// name.
+var ErrNotFound = errors.New("camo: secret not found")
+
+// Provider resolves secrets by name from a source, such as the environment,
+// files, or a secrets manager.
+//
+// Implementations must return an error wrapping ErrNotFound when there is no
+// secret with the given name, so that a Chain can move on to the next
+// Provider.
+type Provider interface {
+	Get(ctx context.Context, name string) (Secret[string], error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ctx context.Context, name string) (Secret[string], error)
+
+// Get calls f(ctx, name).
+func (f ProviderFunc) Get(ctx context.Context, name string) (Secret[string], error) {
+	return f(ctx, name)
+}
+
+func notFound(name string) error {
+	return fmt.Errorf("%w: %q", ErrNotFound, name)
+}
+
+// EnvProvider resolves secrets from environment variables named Prefix
+// followed by the name of the secret.
+type EnvProvider struct {
+	Prefix string
+
+	// Unset unsets each variable once it has been read, like FromEnv.
+	Unset bool
+}
+
+// Get returns the value of the environment variable for name.
+func (p EnvProvider) Get(ctx context.Context, name string) (Secret[string], error) {
+	key := p.Prefix + name
+	if p.Unset {
+		if s, ok := FromEnv(key); ok {
+			return s, nil
+		}
+		return Secret[string]{}, notFound(name)
+	}
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return Secret[string]{}, notFound(name)
+	}
+	return Obscure(value), nil
+}
+
+// FileProvider resolves secrets from files in Dir named after the secret,
+// such as the files in a Docker or Kubernetes secret mount. The files are read
+// with FromFile and the given Options.
+type FileProvider struct {
+	Dir     string
+	Options []Option
+}
+
+// Get returns the content of the file for name. The name must be a local
+// path, so that it can't refer to a file outside of Dir.
+func (p FileProvider) Get(ctx context.Context, name string) (Secret[string], error) {
+	if !filepath.IsLocal(name) {
+		return Secret[string]{}, fmt.Errorf("camo: invalid secret name %q", name)
+	}
+	s, err := FromFile(filepath.Join(p.Dir, name), p.Options...)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Secret[string]{}, notFound(name)
+	}
+	if err != nil {
+		return Secret[string]{}, err
+	}
+	return convert[string](s), nil
+}
+
+// ExecProvider resolves secrets by running a command, such as a password
+// manager's CLI, with the name of the secret appended to Args. The secret is
+// read from the command's standard output, with trailing white space
+// trimmed. As the command's failures can't be told apart, ExecProvider never
+// returns ErrNotFound.
+type ExecProvider struct {
+	Command string
+	Args    []string
+}
+
+// Get runs the command for name and returns its output.
+func (p ExecProvider) Get(ctx context.Context, name string) (Secret[string], error) {
+	args := append(p.Args[:len(p.Args):len(p.Args)], name)
+	cmd := exec.CommandContext(ctx, p.Command, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		// The error doesn't include the output, but the captured standard
+		// error may hold part of a secret.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			wipe(exitErr.Stderr)
+		}
+		wipe(out)
+		return Secret[string]{}, fmt.Errorf("camo: running %s for %q: %w", p.Command, name, err)
+	}
+	return obscureOwned[string](trimTrailingSpace(out)), nil
+}
+
+// Chain is a Provider that tries each of its Providers in order, returning
+// the first secret that is found. It stops at the first error that doesn't
+// wrap ErrNotFound.
+type Chain []Provider
+
+// Get returns the secret for name from the first Provider that has it.
+func (c Chain) Get(ctx context.Context, name string) (Secret[string], error) {
+	for _, p := range c {
+		s, err := p.Get(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return s, err
+	}
+	return Secret[string]{}, notFound(name)
+}
+
+// convert returns s as a Secret of another type with the same content, which
+// is possible without copying as the content is always stored as a string.
+func convert[To, From Obscurable](s Secret[From]) Secret[To] {
+	return *(*Secret[To])(unsafe.Pointer(&s))
+}
@@ -0,0 +1,97 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("CAMO_TEST_DB_PASSWORD", "hunter2")
+	p := EnvProvider{Prefix: "CAMO_TEST_"}
+	s, err := p.Get(context.Background(), "DB_PASSWORD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if _, err := p.Get(context.Background(), "MISSING"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+
+	p.Unset = true
+	if _, err := p.Get(context.Background(), "DB_PASSWORD"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := os.LookupEnv("CAMO_TEST_DB_PASSWORD"); ok {
+		t.Errorf("expected variable to be unset")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db-password"), []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := FileProvider{Dir: dir, Options: []Option{Trimmed()}}
+	s, err := p.Get(context.Background(), "db-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if s != Obscure("hunter2") {
+		t.Errorf("expected secret to equal one obscured from a string")
+	}
+	if _, err := p.Get(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+	if _, err := p.Get(context.Background(), "../db-password"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want an invalid name error", err)
+	}
+}
+
+func TestExecProvider(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	p := ExecProvider{Command: "sh", Args: []string{"-c", `test "$0" = db-password && echo hunter2`}}
+	s, err := p.Get(context.Background(), "db-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if _, err := p.Get(context.Background(), "other"); err == nil {
+		t.Errorf("expected an error when the command fails")
+	}
+}
+
+func TestChain(t *testing.T) {
+	missing := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Secret[string]{}, notFound(name)
+	})
+	found := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Obscure("hunter2"), nil
+	})
+	broken := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		return Secret[string]{}, errors.New("broken")
+	})
+
+	s, err := Chain{missing, found, broken}.Get(context.Background(), "x")
+	if err != nil || s != Obscure("hunter2") {
+		t.Errorf("Get() = %v, %v; want the secret from the second provider", s, err)
+	}
+	if _, err := (Chain{missing, broken, found}).Get(context.Background(), "x"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want the error from the second provider", err)
+	}
+	if _, err := (Chain{missing}).Get(context.Background(), "x"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,21 @@
+package camo
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+var errNegativeLength = errors.New("camo: negative length")
+
+// GenerateRandom returns a Secret holding n bytes read from crypto/rand,
+// suitable for use as a key, salt or nonce.
+func GenerateRandom(n int) (Secret[[]byte], error) {
+	if n < 0 {
+		return Secret[[]byte]{}, errNegativeLength
+	}
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return Secret[[]byte]{}, err
+	}
+	return obscureOwned[[]byte](buf), nil
+}
@@ -0,0 +1,27 @@
+package camo
+
+import "testing"
+
+func TestGenerateRandom(t *testing.T) {
+	a, err := GenerateRandom(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := GenerateRandom(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.Reveal()); got != 32 {
+		t.Errorf("len = %d; want 32", got)
+	}
+	if a == b {
+		t.Errorf("expected two random secrets to differ")
+	}
+	if _, err := GenerateRandom(-1); err == nil {
+		t.Errorf("expected an error for a negative length")
+	}
+	empty, err := GenerateRandom(0)
+	if err != nil || !empty.Valid() {
+		t.Errorf("GenerateRandom(0) = %v, %v; want a valid empty secret", empty, err)
+	}
+}
@@ -0,0 +1,89 @@
+package camo
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when the content of a RateLimitedSecret is
+// extracted more often than its limit allows.
+var ErrRateLimited = errors.New("camo: secret revealed too often")
+
+// RateLimitedSecret is a Secret whose content can only be extracted at a
+// limited rate, which catches code paths that extract a credential in a hot
+// loop rather than holding on to what they need.
+//
+// The rate is enforced with a token bucket: each extraction takes a token,
+// and tokens are added at a steady rate up to a maximum burst.
+//
+// It is safe for concurrent use.
+type RateLimitedSecret[O Obscurable] struct {
+	s     Secret[O]
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// RateLimit returns a RateLimitedSecret that allows the content of s to be
+// extracted rate times per second on average, with bursts of up to burst
+// extractions.
+func RateLimit[O Obscurable](s Secret[O], rate float64, burst int) *RateLimitedSecret[O] {
+	return &RateLimitedSecret[O]{
+		s:      s,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Reveal returns the underlying secret data like Secret.Reveal, or
+// ErrRateLimited if the limit has been reached.
+func (r *RateLimitedSecret[O]) Reveal() (O, error) {
+	if !r.allow() {
+		var zero O
+		return zero, ErrRateLimited
+	}
+	return r.s.Reveal(), nil
+}
+
+// MustReveal is like Reveal, but panics if the limit has been reached.
+func (r *RateLimitedSecret[O]) MustReveal() O {
+	content, err := r.Reveal()
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// AppendTo appends the secret to the byte slice like Secret.AppendTo, or
+// returns dst unchanged and ErrRateLimited if the limit has been reached.
+func (r *RateLimitedSecret[O]) AppendTo(dst []byte) ([]byte, error) {
+	if !r.allow() {
+		return dst, ErrRateLimited
+	}
+	return r.s.AppendTo(dst), nil
+}
+
+// Valid reports if the Secret is valid.
+func (r *RateLimitedSecret[O]) Valid() bool {
+	return r.s.Valid()
+}
+
+// allow takes a token from the bucket, if there is one.
+func (r *RateLimitedSecret[O]) allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.tokens = min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.rate)
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
@@ -0,0 +1,38 @@
+package camo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	r := RateLimit(Obscure("hunter2"), 1000, 2)
+	for range 2 {
+		if got, err := r.Reveal(); err != nil || got != "hunter2" {
+			t.Fatalf("Reveal() = %q, %v; want %q", got, err, "hunter2")
+		}
+	}
+	if _, err := r.Reveal(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("err = %v; want ErrRateLimited", err)
+	}
+	if dst, err := r.AppendTo([]byte("x")); !errors.Is(err, ErrRateLimited) || string(dst) != "x" {
+		t.Errorf("AppendTo() = %q, %v; want %q, ErrRateLimited", dst, err, "x")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if dst, err := r.AppendTo(nil); err != nil || string(dst) != "hunter2" {
+		t.Errorf("AppendTo() = %q, %v after tokens were added", dst, err)
+	}
+}
+
+func TestRateLimitMustReveal(t *testing.T) {
+	r := RateLimit(Obscure([]byte("hunter2")), 0, 1)
+	r.MustReveal()
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("recovered %v; want ErrRateLimited", err)
+		}
+	}()
+	r.MustReveal()
+}
@@ -0,0 +1,82 @@
+package camo
+
+import (
+	"errors"
+	"io"
+	"math"
+	"runtime"
+)
+
+// ErrTooLarge is returned by ObscureReader when the content exceeds the
+// size limit.
+var ErrTooLarge = errors.New("camo: secret exceeds the size limit")
+
+// ObscureReader returns a Secret holding the content read from r until EOF,
+// such as from a pipe, an HTTP request body, or stdin. The content is read
+// into buffers that are wiped once they are no longer needed, so it never
+// passes through memory owned by the caller. If more than max bytes are
+// read, ErrTooLarge is returned.
+func ObscureReader(r io.Reader, max int64, opts ...Option) (Secret[[]byte], error) {
+	if max < 0 {
+		return Secret[[]byte]{}, errNegativeLength
+	}
+	// Reading one more byte than max shows whether the content exceeds it,
+	// which no content can if max is the largest int64.
+	if max < math.MaxInt64 {
+		r = io.LimitReader(r, max+1)
+	}
+	buf, err := readAll(r, min(max, 512))
+	if err != nil {
+		return Secret[[]byte]{}, err
+	}
+	if int64(len(buf)) > max {
+		wipe(buf)
+		return Secret[[]byte]{}, ErrTooLarge
+	}
+	return obscureOwned[[]byte](buf, opts...), nil
+}
+
+// Reader returns an io.ReadCloser that reads a copy of the secret, for APIs
+// that only accept readers, such as multipart uploads. The copy is wiped as
+// soon as it has been read in full or the reader is closed, and once the
+// reader is no longer reachable otherwise. It panics if the secret is zero.
+func (s Secret[O]) Reader() io.ReadCloser {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("Reader")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("Reader")
+	buf := []byte(s.str())
+	r := &secretReader{buf: buf}
+	if len(buf) > 0 {
+		runtime.AddCleanup(r, wipe, buf)
+	}
+	return r
+}
+
+// secretReader reads from buf, wiping it once it has been read in full.
+type secretReader struct {
+	buf []byte
+	off int
+}
+
+func (r *secretReader) Read(p []byte) (int, error) {
+	if r.off >= len(r.buf) {
+		r.Close()
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf[r.off:])
+	r.off += n
+	if r.off == len(r.buf) {
+		r.Close()
+	}
+	return n, nil
+}
+
+// Close wipes the copy of the secret. Reading after Close returns io.EOF.
+func (r *secretReader) Close() error {
+	wipe(r.buf)
+	r.buf, r.off = nil, 0
+	return nil
+}
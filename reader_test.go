@@ -0,0 +1,79 @@
+package camo
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReader(t *testing.T) {
+	r := Obscure("hunter2").Reader()
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "hunter2" {
+		t.Errorf("got = %q, %v; want %q", got, err, "hunter2")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.Reader() }); !ok {
+		t.Errorf("expected zero.Reader() to panic")
+	}
+}
+
+func TestReaderWipes(t *testing.T) {
+	r := Obscure([]byte("hunter2")).Reader().(*secretReader)
+	buf := r.buf
+	p := make([]byte, 4)
+	if n, _ := r.Read(p); n != 4 || bytes.Equal(buf, make([]byte, 7)) {
+		t.Errorf("expected the copy to be intact after a partial read")
+	}
+	if n, _ := r.Read(p); n != 3 || string(p[:n]) != "er2" {
+		t.Errorf("got = %q; want %q", p[:n], "er2")
+	}
+	if !bytes.Equal(buf, make([]byte, 7)) {
+		t.Errorf("expected the copy to be wiped after it was read in full, got %q", buf)
+	}
+	if n, err := r.Read(p); n != 0 || err != io.EOF {
+		t.Errorf("got = %d, %v; want 0, EOF", n, err)
+	}
+
+	r = Obscure("hunter2").Reader().(*secretReader)
+	buf = r.buf
+	r.Read(p)
+	r.Close()
+	if !bytes.Equal(buf, make([]byte, 7)) {
+		t.Errorf("expected the copy to be wiped on Close, got %q", buf)
+	}
+	if n, err := r.Read(p); n != 0 || err != io.EOF {
+		t.Errorf("got = %d, %v after Close; want 0, EOF", n, err)
+	}
+}
+
+func TestObscureReader(t *testing.T) {
+	s, err := ObscureReader(strings.NewReader("hunter2\n"), 8, Trimmed())
+	if err != nil || s != Obscure([]byte("hunter2")) {
+		t.Errorf("got = %q, %v; want %q", s.Reveal(), err, "hunter2")
+	}
+	big := strings.Repeat("x", 5000)
+	s, err = ObscureReader(strings.NewReader(big), 5000)
+	if err != nil || string(s.Reveal()) != big {
+		t.Errorf("got %d bytes, %v; want %d bytes", len(s.Reveal()), err, len(big))
+	}
+	if _, err := ObscureReader(strings.NewReader("hunter2\n"), 7); err != ErrTooLarge {
+		t.Errorf("got err = %v; want %v", err, ErrTooLarge)
+	}
+	if _, err := ObscureReader(iotest.ErrReader(io.ErrUnexpectedEOF), 8); err != io.ErrUnexpectedEOF {
+		t.Errorf("got err = %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	if s, err := ObscureReader(strings.NewReader(""), 0); err != nil || !s.Valid() || s.Len() != 0 {
+		t.Errorf("expected an empty Secret, got %v", err)
+	}
+	if s, err := ObscureReader(strings.NewReader("hunter2"), math.MaxInt64); err != nil || s != Obscure([]byte("hunter2")) {
+		t.Errorf("got = %q, %v with no limit; want %q", s.Reveal(), err, "hunter2")
+	}
+}
@@ -0,0 +1,126 @@
+package camo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Redactor is implemented by types that provide their own redacted
+// representation to Redact.
+type Redactor interface {
+	// Redact returns a representation of the value that is safe to log.
+	Redact() any
+}
+
+var (
+	redactorType = reflect.TypeFor[Redactor]()
+	errorType    = reflect.TypeFor[error]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+)
+
+// Redact returns a deep copy of v that is safe to log, such as a request or a
+// config struct. The copy is made of basic Go values, so it can be printed or
+// marshaled to JSON:
+//
+//   - Secrets are replaced with "REDACTED";
+//   - strings and byte slices are scrubbed (see Scrub);
+//   - values implementing Redactor are replaced with the result of their
+//     Redact method;
+//   - errors are replaced with their scrubbed messages;
+//   - structs are copied to a map[string]any of their exported fields,
+//     unless they have none, in which case they are replaced with their
+//     scrubbed String method, if they have one;
+//   - maps are copied to a map[string]any, with keys formatted by fmt and
+//     scrubbed;
+//   - slices and arrays are copied to a []any;
+//   - pointers and interfaces are replaced with the values they point to;
+//   - functions, channels, and unsafe pointers are replaced with nil.
+//
+// Cycles of pointers are broken by replacing a pointer that is being copied
+// with nil.
+func Redact(v any) any {
+	return redact(reflect.ValueOf(v), make(map[uintptr]bool))
+}
+
+func redact(v reflect.Value, seen map[uintptr]bool) any {
+	if !v.IsValid() {
+		return nil
+	}
+	t := v.Type()
+	if t == stringSecretType || t == bytesSecretType {
+		return Redacted
+	}
+	if t.Implements(redactorType) && v.CanInterface() && !isNilPointer(v) {
+		return v.Interface().(Redactor).Redact()
+	}
+	if t.Implements(errorType) && v.CanInterface() && !isNilPointer(v) {
+		return RedactError(v.Interface().(error)).Error()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return Scrub(v.String())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex()
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		defer delete(seen, v.Pointer())
+		return redact(v.Elem(), seen)
+	case reflect.Interface:
+		return redact(v.Elem(), seen)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return []byte(Scrub(string(v.Bytes())))
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redact(v.Index(i), seen)
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			out[Scrub(fmt.Sprint(redact(iter.Key(), seen)))] = redact(iter.Value(), seen)
+		}
+		return out
+	case reflect.Struct:
+		out := make(map[string]any)
+		for i := range t.NumField() {
+			if sf := t.Field(i); sf.IsExported() {
+				out[sf.Name] = redact(v.Field(i), seen)
+			}
+		}
+		if len(out) == 0 && t.Implements(stringerType) && v.CanInterface() {
+			return Scrub(v.Interface().(fmt.Stringer).String())
+		}
+		return out
+	}
+	return nil
+}
+
+func isNilPointer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package camo
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// scrubMask replaces the content of registered secrets in scrubbed output.
+const scrubMask = "***"
+
+var registry struct {
+	mu      sync.Mutex
+	entries map[string]*registryEntry
+
+	// scrubber is rebuilt whenever contents changes, so that scrubbing never
+	// needs to take the lock.
+	scrubber atomic.Pointer[scrubber]
+}
+
+type scrubber struct {
+	replacer *strings.Replacer
+
+	// contents is ordered from longest to shortest.
+	contents []string
+
+	// entries keeps the entries of contents from being wiped while the
+	// scrubber is in use, so it must be kept alive until then.
+	entries []*registryEntry
+}
+
+// registryEntry is a registered content, which is shared by the secrets with
+// that content. Its copy of the content is wiped once it has been
+// unregistered by each of them and the scrubbers using it are no longer
+// reachable.
+type registryEntry struct {
+	content string
+	refs    int
+}
+
+// Register adds the content of s to the process-wide registry of secrets that
+// are scrubbed from output by Scrub and the scrubbing wrappers in this
+// package. This catches the content of a secret after it has been revealed,
+// such as when it ends up in an error message or log line.
+//
+// The registrations are counted, so that content that is registered more
+// than once, such as by several secrets with the same content, is scrubbed
+// until it has been unregistered as many times. Nil, zero, and empty secrets
+// are ignored.
+func Register(s AnySecret) {
+	if s == nil || !s.Valid() || s.str() == "" {
+		return
+	}
+	defer runtime.KeepAlive(s)
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if e, ok := registry.entries[s.str()]; ok {
+		e.refs++
+		return
+	}
+	if registry.entries == nil {
+		registry.entries = make(map[string]*registryEntry)
+	}
+	buf := []byte(s.str())
+	e := &registryEntry{
+		content: unsafe.String(unsafe.SliceData(buf), len(buf)),
+		refs:    1,
+	}
+	runtime.AddCleanup(e, wipe, buf)
+	registry.entries[e.content] = e
+	rebuildScrubber()
+}
+
+// Unregister removes a registration of the content of s from the registry.
+// The content is no longer scrubbed once every registration of it has been
+// removed.
+func Unregister(s AnySecret) {
+	if s == nil || !s.Valid() {
+		return
+	}
+	defer runtime.KeepAlive(s)
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	e, ok := registry.entries[s.str()]
+	if !ok {
+		return
+	}
+	if e.refs--; e.refs > 0 {
+		return
+	}
+	delete(registry.entries, e.content)
+	rebuildScrubber()
+}
+
+// rebuildScrubber must be called with registry.mu held.
+func rebuildScrubber() {
+	if len(registry.entries) == 0 {
+		registry.scrubber.Store(nil)
+		return
+	}
+	contents := make([]string, 0, len(registry.entries))
+	entries := make([]*registryEntry, 0, len(registry.entries))
+	for c, e := range registry.entries {
+		contents = append(contents, c)
+		entries = append(entries, e)
+	}
+	// The replacer prefers earlier arguments when several match at the same
+	// position, so longer contents must come first for a secret that is a
+	// prefix of another to not leave part of the longer one behind.
+	sort.Slice(contents, func(i, j int) bool {
+		return len(contents[i]) > len(contents[j])
+	})
+	oldnew := make([]string, 0, 2*len(contents))
+	for _, c := range contents {
+		oldnew = append(oldnew, c, scrubMask)
+	}
+	registry.scrubber.Store(&scrubber{
+		replacer: strings.NewReplacer(oldnew...),
+		contents: contents,
+		entries:  entries,
+	})
+}
+
+// Scrub returns str with every occurrence of the content of a registered
+// secret replaced with "***".
+func Scrub(str string) string {
+	sc := registry.scrubber.Load()
+	if sc == nil {
+		return str
+	}
+	defer runtime.KeepAlive(sc)
+	scrubbed := sc.replacer.Replace(str)
+	if scrubbed != str {
+		stats.scrubHits.Add(1)
+	}
+	return scrubbed
+}
@@ -0,0 +1,68 @@
+package camo
+
+import "testing"
+
+func TestScrub(t *testing.T) {
+	a := Obscure("hunter2")
+	b := Obscure([]byte("hunter2hunter3"))
+	Register(a)
+	Register(b)
+	t.Cleanup(func() {
+		Unregister(a)
+		Unregister(b)
+	})
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"nothing to see", "nothing to see"},
+		{"password=hunter2", "password=***"},
+		{"hunter2 hunter2", "*** ***"},
+		{"key=hunter2hunter3!", "key=***!"},
+	}
+	for _, tc := range cases {
+		if got := Scrub(tc.in); got != tc.want {
+			t.Errorf("Scrub(%q) = %q; want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	s := Obscure("hunter2")
+	Register(s)
+	Unregister(s)
+	if got := Scrub("hunter2"); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestRegisterCountsRegistrations(t *testing.T) {
+	a, b := Obscure("hunter2"), Obscure([]byte("hunter2"))
+	Register(a)
+	Register(b)
+	Unregister(a)
+	if got := Scrub("hunter2"); got != "***" {
+		t.Errorf("expected the content to be scrubbed while b is registered, got %q", got)
+	}
+	Unregister(b)
+	if got := Scrub("hunter2"); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	// Unregistering content that isn't registered has no effect.
+	Unregister(a)
+	Register(a)
+	defer Unregister(a)
+	if got := Scrub("hunter2"); got != "***" {
+		t.Errorf("expected the content to be registered again, got %q", got)
+	}
+}
+
+func TestRegisterIgnoresZeroAndEmpty(t *testing.T) {
+	Register(Secret[string]{})
+	Register(Obscure(""))
+	if got := Scrub("abc"); got != "abc" {
+		t.Errorf("got = %q; want %q", got, "abc")
+	}
+}
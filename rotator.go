@@ -0,0 +1,201 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errRotatorClosed = errors.New("camo: rotator is closed")
+
+// Rotator periodically resolves secrets through a Provider again, so that
+// components holding credentials can react to rotation without polling the
+// Provider themselves.
+//
+// It is safe for concurrent use.
+type Rotator struct {
+	provider Provider
+	interval time.Duration
+
+	mu      sync.Mutex
+	secrets map[string]*RotatingSecret
+	closed  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRotator returns a Rotator that resolves its secrets through p every
+// interval until Close is called.
+func NewRotator(p Provider, interval time.Duration) *Rotator {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Rotator{
+		provider: p,
+		interval: interval,
+		secrets:  make(map[string]*RotatingSecret),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go r.poll()
+	return r
+}
+
+// Add resolves the secret with the given name and returns a RotatingSecret
+// that is kept up to date until the Rotator is closed. It returns an error if
+// the initial lookup fails. Adding the same name again returns the same
+// RotatingSecret.
+func (r *Rotator) Add(ctx context.Context, name string) (*RotatingSecret, error) {
+	r.mu.Lock()
+	rs, ok := r.secrets[name]
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, errRotatorClosed
+	}
+	if ok {
+		return rs, nil
+	}
+
+	s, err := r.provider.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, errRotatorClosed
+	}
+	if rs, ok := r.secrets[name]; ok {
+		// Lost a race with another call to Add.
+		return rs, nil
+	}
+	rs = &RotatingSecret{name: name}
+	rs.current.Store(&s)
+	r.secrets[name] = rs
+	return rs, nil
+}
+
+// Close stops rotating secrets. The RotatingSecrets keep returning the last
+// secret that was resolved.
+func (r *Rotator) Close() error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		r.cancel()
+	}
+	r.mu.Unlock()
+	<-r.done
+	return nil
+}
+
+func (r *Rotator) poll() {
+	defer close(r.done)
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-t.C:
+			r.rotate()
+		}
+	}
+}
+
+// rotate resolves each secret again and notifies subscribers of changes.
+func (r *Rotator) rotate() {
+	r.mu.Lock()
+	secrets := make([]*RotatingSecret, 0, len(r.secrets))
+	for _, rs := range r.secrets {
+		secrets = append(secrets, rs)
+	}
+	r.mu.Unlock()
+
+	for _, rs := range secrets {
+		if r.ctx.Err() != nil {
+			return
+		}
+		s, err := r.provider.Get(r.ctx, rs.name)
+		if err != nil {
+			if r.ctx.Err() == nil {
+				rs.err.Store(&err)
+			}
+			continue
+		}
+		rs.err.Store(new(error))
+		if old := rs.current.Swap(&s); *old != s {
+			rs.notify(*old, s)
+		}
+	}
+}
+
+// RotatingSecret is a secret that is kept up to date by a Rotator.
+//
+// It is safe for concurrent use.
+type RotatingSecret struct {
+	name string
+
+	current atomic.Pointer[Secret[string]]
+	err     atomic.Pointer[error]
+
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]func(old, new Secret[string])
+}
+
+// Name returns the name the secret is resolved with.
+func (rs *RotatingSecret) Name() string {
+	return rs.name
+}
+
+// Load returns the most recently resolved secret.
+func (rs *RotatingSecret) Load() Secret[string] {
+	return *rs.current.Load()
+}
+
+// Err returns the error from the most recent attempt to resolve the secret,
+// or nil if it succeeded. Load keeps returning the last secret that was
+// resolved while resolving fails.
+func (rs *RotatingSecret) Err() error {
+	if err := rs.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// OnChange registers f to be called with the old and new secret whenever the
+// secret changes, and returns a function that unregisters it. Callbacks are
+// called one at a time from the Rotator's goroutine, so they should return
+// promptly.
+func (rs *RotatingSecret) OnChange(f func(old, new Secret[string])) (cancel func()) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.subs == nil {
+		rs.subs = make(map[int]func(old, new Secret[string]))
+	}
+	id := rs.nextID
+	rs.nextID++
+	rs.subs[id] = f
+	return func() {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		delete(rs.subs, id)
+	}
+}
+
+func (rs *RotatingSecret) notify(old, new Secret[string]) {
+	rs.mu.Lock()
+	subs := make([]func(old, new Secret[string]), 0, len(rs.subs))
+	for _, f := range rs.subs {
+		subs = append(subs, f)
+	}
+	rs.mu.Unlock()
+	for _, f := range subs {
+		f(old, new)
+	}
+}
@@ -0,0 +1,79 @@
+package camo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRotator(t *testing.T) {
+	var (
+		version atomic.Int32
+		fail    atomic.Bool
+	)
+	version.Store(1)
+	p := ProviderFunc(func(ctx context.Context, name string) (Secret[string], error) {
+		if name == "missing" {
+			return Secret[string]{}, notFound(name)
+		}
+		if fail.Load() {
+			return Secret[string]{}, errors.New("unavailable")
+		}
+		return Obscure(name + string(rune('0'+version.Load()))), nil
+	})
+	r := NewRotator(p, time.Millisecond)
+	defer r.Close()
+
+	rs, err := r.Add(context.Background(), "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := r.Add(context.Background(), "db"); again != rs {
+		t.Errorf("expected the same RotatingSecret for the same name")
+	}
+	if _, err := r.Add(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v; want ErrNotFound", err)
+	}
+
+	changes := make(chan [2]string, 10)
+	rs.OnChange(func(old, new Secret[string]) {
+		changes <- [2]string{old.Reveal(), new.Reveal()}
+	})
+	version.Store(2)
+	select {
+	case got := <-changes:
+		if got != [2]string{"db1", "db2"} {
+			t.Errorf("OnChange called with %q; want [db1 db2]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnChange not called")
+	}
+	if got := rs.Load().Reveal(); got != "db2" {
+		t.Errorf("Load() = %q; want %q", got, "db2")
+	}
+
+	fail.Store(true)
+	waitFor(t, func() bool { return rs.Err() != nil })
+	if got := rs.Load().Reveal(); got != "db2" {
+		t.Errorf("Load() = %q; want the last resolved secret", got)
+	}
+
+	r.Close()
+	if _, err := r.Add(context.Background(), "other"); err == nil {
+		t.Errorf("expected an error adding to a closed Rotator")
+	}
+}
+
+func TestRotatingSecretCancel(t *testing.T) {
+	rs := &RotatingSecret{}
+	var calls int
+	cancel := rs.OnChange(func(old, new Secret[string]) { calls++ })
+	rs.notify(Obscure("a"), Obscure("b"))
+	cancel()
+	rs.notify(Obscure("b"), Obscure("c"))
+	if calls != 1 {
+		t.Errorf("callback called %d times; want 1", calls)
+	}
+}
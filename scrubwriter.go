@@ -0,0 +1,117 @@
+package camo
+
+import (
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ScrubWriter is an io.Writer that scrubs the content of registered secrets
+// (see Register) from everything written through it, replacing each
+// occurrence with "***". It is safe for concurrent use.
+//
+// Occurrences that are split across multiple calls to Write are also
+// scrubbed. To achieve this, a trailing part of a write that could be the
+// start of a registered secret is held back until a later write shows
+// whether it is, so Flush must be called once writing is done. Output that
+// can't be the start of a registered secret is never held back.
+type ScrubWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	pending []byte
+}
+
+// NewScrubWriter returns a ScrubWriter that writes to w.
+func NewScrubWriter(w io.Writer) *ScrubWriter {
+	return &ScrubWriter{w: w}
+}
+
+// Write implements io.Writer.
+func (w *ScrubWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	sc := registry.scrubber.Load()
+	if sc == nil {
+		if err := w.flushLocked(); err != nil {
+			return 0, err
+		}
+		if _, err := w.w.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	defer runtime.KeepAlive(sc)
+	buf := append(w.pending, p...)
+	out, held := sc.scrub(buf)
+	w.pending = append(w.pending[:0], held...)
+	if _, err := w.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes out anything held back by previous writes. What it writes is
+// never more than a prefix of the content of a registered secret.
+func (w *ScrubWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked()
+}
+
+func (w *ScrubWriter) flushLocked() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(w.pending)
+	w.pending = w.pending[:0]
+	return err
+}
+
+// scrub returns buf with every complete occurrence of registered content
+// replaced, and the trailing part of buf that is a prefix of registered
+// content, which must be held back until more data is available.
+func (sc *scrubber) scrub(buf []byte) (out, held []byte) {
+	out = make([]byte, 0, len(buf))
+	for i := 0; i < len(buf); {
+		rest := buf[i:]
+		if n := sc.matchAt(rest); n > 0 {
+			out = append(out, scrubMask...)
+			stats.scrubHits.Add(1)
+			i += n
+			continue
+		}
+		if sc.isPrefix(rest) {
+			return out, rest
+		}
+		out = append(out, buf[i])
+		i++
+	}
+	return out, nil
+}
+
+// matchAt returns the length of the longest registered content at the start
+// of b, or 0 if there is none.
+func (sc *scrubber) matchAt(b []byte) int {
+	for _, c := range sc.contents {
+		if len(c) <= len(b) && string(b[:len(c)]) == c {
+			return len(c)
+		}
+	}
+	return 0
+}
+
+// isPrefix reports if b is a proper prefix of any registered content.
+func (sc *scrubber) isPrefix(b []byte) bool {
+	for _, c := range sc.contents {
+		if len(b) >= len(c) {
+			// contents is ordered from longest to shortest.
+			return false
+		}
+		if c[:len(b)] == string(b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package camo
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScrubWriter(t *testing.T) {
+	s := Obscure("hunter2")
+	Register(s)
+	t.Cleanup(func() { Unregister(s) })
+
+	cases := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{"single", []string{"password=hunter2\n"}, "password=***\n"},
+		{"clean", []string{"hello ", "world"}, "hello world"},
+		{"split", []string{"password=hun", "ter2\n"}, "password=***\n"},
+		{"bytewise", []string{"h", "u", "n", "t", "e", "r", "2", "!"}, "***!"},
+		{"false start", []string{"hunt", "ing"}, "hunting"},
+		{"trailing prefix", []string{"ends with hunt"}, "ends with hunt"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewScrubWriter(&buf)
+			for _, p := range tc.writes {
+				n, err := w.Write([]byte(p))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if n != len(p) {
+					t.Errorf("n = %d; want %d", n, len(p))
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScrubWriterHoldsBackOnlyPrefixes(t *testing.T) {
+	s := Obscure("hunter2")
+	Register(s)
+	t.Cleanup(func() { Unregister(s) })
+
+	var buf bytes.Buffer
+	w := NewScrubWriter(&buf)
+	w.Write([]byte("prompt> "))
+	if got := buf.String(); got != "prompt> " {
+		t.Errorf("got = %q; want output to be written immediately", got)
+	}
+	w.Write([]byte("hun"))
+	if got := buf.String(); got != "prompt> " {
+		t.Errorf("got = %q; want possible prefix to be held back", got)
+	}
+}
+
+func TestScrubWriterWithEmptyRegistry(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewScrubWriter(&buf)
+	w.Write([]byte("hunter2"))
+	if got := buf.String(); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+}
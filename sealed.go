@@ -0,0 +1,203 @@
+package camo
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// SealedSecret is like Secret, but its content is kept encrypted in memory
+// with an ephemeral per-process key, and is only decrypted transiently by the
+// methods that need the plaintext. This protects the content from heap dumps
+// and memory scraping far better than Secret alone, at the cost of
+// decrypting on every use.
+//
+// Like Secret, it is immutable, comparable, and its zero value is distinct
+// from an empty secret.
+type SealedSecret[O Obscurable] struct {
+	_ unsafe.Pointer
+
+	hash uint64
+}
+
+// The enclave key is split into two random halves that are combined only
+// while a cipher is being created, so that it never appears contiguously in
+// memory for long.
+var enclaveKey = sync.OnceValue(func() [2][]byte {
+	var halves [2][]byte
+	for i := range halves {
+		halves[i] = make([]byte, 32)
+		if _, err := rand.Read(halves[i]); err != nil {
+			panic(fmt.Sprintf("camo: generating enclave key: %v", err))
+		}
+	}
+	return halves
+})
+
+func enclaveAEAD() cipher.AEAD {
+	halves := enclaveKey()
+	var key [32]byte
+	defer clear(key[:])
+	for i := range key {
+		key[i] = halves[0][i] ^ halves[1][i]
+	}
+	aead, err := newAESGCM(key[:])
+	if err != nil {
+		panic(fmt.Sprintf("camo: creating enclave cipher: %v", err))
+	}
+	return aead
+}
+
+// ObscureSealed returns a SealedSecret that wraps an encrypted copy of the
+// given content.
+func ObscureSealed[O Obscurable](content O) SealedSecret[O] {
+	plaintext := append([]byte(nil), content...)
+	defer clear(plaintext)
+	return newSealedSecret[O](plaintext, policy{})
+}
+
+// newSealedSecret returns a SealedSecret with the given content and policy.
+// Its box holds the ciphertext as its content.
+func newSealedSecret[O Obscurable](plaintext []byte, pol policy) SealedSecret[O] {
+	ciphertext, err := seal(enclaveAEAD(), plaintext, nil)
+	if err != nil {
+		panic(fmt.Sprintf("camo: sealing secret: %v", err))
+	}
+	b := &box{
+		content: unsafe.String(unsafe.SliceData(ciphertext), len(ciphertext)),
+		policy:  pol,
+	}
+	hash := pol.hash
+	if hash == nil {
+		hash = hashContent
+	}
+	s := secret{
+		p:    unsafe.Pointer(b),
+		hash: hash(unsafe.String(unsafe.SliceData(plaintext), len(plaintext))),
+	}
+	return *(*SealedSecret[O])(unsafe.Pointer(&s))
+}
+
+// Seal returns a SealedSecret with the same content as s, which keeps the
+// label and policies of s, such as AllowCallers. It panics if the secret is
+// zero.
+func (s Secret[O]) Seal() SealedSecret[O] {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("Seal")
+	}
+	defer runtime.KeepAlive(s)
+	return newSealedSecret[O](s.view(), s.box().policy)
+}
+
+func (s SealedSecret[O]) secret() secret {
+	return *(*secret)(unsafe.Pointer(&s))
+}
+
+func (s SealedSecret[O]) box() *box {
+	return (*box)(s.secret().p)
+}
+
+// Valid reports if the SealedSecret is valid.
+func (s SealedSecret[O]) Valid() bool {
+	return s.secret().p != nil
+}
+
+// open decrypts the content into a newly allocated buffer, which the caller
+// owns. Unless the content stays obscured, as with Unseal, it is extracted,
+// which is reported as by Secret.revealed on behalf of the method that calls
+// open.
+func (s SealedSecret[O]) open(method string, extracted bool) []byte {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero(method)
+	}
+	b := s.box()
+	if extracted {
+		b.revealed(method)
+	}
+	ciphertext := b.content
+	plaintext, err := open(enclaveAEAD(), unsafe.Slice(unsafe.StringData(ciphertext), len(ciphertext)), nil)
+	if err != nil {
+		panic(fmt.Sprintf("camo: opening sealed secret: %v", err))
+	}
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+	return plaintext
+}
+
+// WithRevealed decrypts the content and passes it to f, wiping the decrypted
+// copy once f returns. The content must not be retained by f, including
+// when O is string. It panics if the secret is zero.
+func (s SealedSecret[O]) WithRevealed(f func(O)) {
+	plaintext := s.open("WithRevealed", true)
+	defer clear(plaintext)
+	var content O
+	switch p := any(&content).(type) {
+	case *string:
+		*p = unsafe.String(unsafe.SliceData(plaintext), len(plaintext))
+	case *[]byte:
+		*p = plaintext
+	}
+	f(content)
+}
+
+// Reveal returns a decrypted copy of the content, which is owned by the
+// caller. It panics if the secret is zero.
+func (s SealedSecret[O]) Reveal() O {
+	plaintext := s.open("Reveal", true)
+	return *(*O)(unsafe.Pointer(&plaintext))
+}
+
+// AppendTo appends the decrypted content to dst and returns the updated
+// slice. It panics if the secret is zero.
+func (s SealedSecret[O]) AppendTo(dst []byte) []byte {
+	plaintext := s.open("AppendTo", true)
+	defer clear(plaintext)
+	return append(dst, plaintext...)
+}
+
+// Unseal returns a Secret with the same content as s, which keeps the label
+// and policies of s. It panics if the secret is zero.
+func (s SealedSecret[O]) Unseal() Secret[O] {
+	plaintext := s.open("Unseal", false)
+	return obscureOwned[O](plaintext, withPolicy(s.box().policy))
+}
+
+// String implements fmt.Stringer, returning a redacted representation such
+// as "camo.SealedSecret[string](REDACTED)".
+func (s SealedSecret[O]) String() string {
+	var zero O
+	switch any(zero).(type) {
+	case string:
+		return "camo.SealedSecret[string](" + Redacted + ")"
+	default:
+		return "camo.SealedSecret[[]byte](" + Redacted + ")"
+	}
+}
+
+// GoString implements fmt.GoStringer, returning the same as String.
+func (s SealedSecret[O]) GoString() string {
+	return s.String()
+}
+
+// Format implements fmt.Formatter, so that every verb prints the redacted
+// representation.
+func (s SealedSecret[O]) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), s.String())
+}
+
+// LogValue implements slog.LogValuer, rendering as Redacted.
+func (s SealedSecret[O]) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// MarshalText implements encoding.TextMarshaler, always returning Redacted.
+func (s SealedSecret[O]) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
@@ -0,0 +1,131 @@
+package camo
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestSealedSecret(t *testing.T) {
+	s := ObscureSealed("hunter2")
+	if !s.Valid() {
+		t.Fatalf("expected sealed secret to be valid")
+	}
+	if got := s.Reveal(); got != "hunter2" {
+		t.Errorf("Reveal() = %q; want %q", got, "hunter2")
+	}
+	if got := s.AppendTo([]byte("pw=")); string(got) != "pw=hunter2" {
+		t.Errorf("AppendTo() = %q; want %q", got, "pw=hunter2")
+	}
+	var seen string
+	s.WithRevealed(func(content string) { seen = string([]byte(content)) })
+	if seen != "hunter2" {
+		t.Errorf("WithRevealed saw %q; want %q", seen, "hunter2")
+	}
+}
+
+func TestSealedSecretIsEncrypted(t *testing.T) {
+	s := ObscureSealed([]byte("hunter2"))
+	stored := *(*string)(s.secret().p)
+	if bytes.Contains([]byte(stored), []byte("hunter2")) {
+		t.Errorf("stored content contains the plaintext")
+	}
+}
+
+func TestSealedSecretWithRevealedWipes(t *testing.T) {
+	s := ObscureSealed([]byte("hunter2"))
+	var leaked []byte
+	s.WithRevealed(func(content []byte) { leaked = content })
+	if !bytes.Equal(leaked, make([]byte, len("hunter2"))) {
+		t.Errorf("expected the revealed buffer to be wiped, got %q", leaked)
+	}
+}
+
+func TestSealedSecretEquality(t *testing.T) {
+	if ObscureSealed("a") != ObscureSealed("a") {
+		t.Errorf("expected sealed secrets with equal content to be equal")
+	}
+	if ObscureSealed("a") == ObscureSealed("b") {
+		t.Errorf("expected sealed secrets with different content to differ")
+	}
+	if ObscureSealed("") == (SealedSecret[string]{}) {
+		t.Errorf("expected empty sealed secret to differ from zero")
+	}
+}
+
+func TestSealAndUnseal(t *testing.T) {
+	s := Obscure([]byte{0, 1, 2})
+	sealed := s.Seal()
+	if got := sealed.Reveal(); !bytes.Equal(got, []byte{0, 1, 2}) {
+		t.Errorf("got = %v; want %v", got, []byte{0, 1, 2})
+	}
+	if got := sealed.Unseal(); got != s {
+		t.Errorf("expected Unseal to return an equal Secret")
+	}
+	if got := ObscureSealed("").Unseal().Reveal(); got != "" {
+		t.Errorf("got = %q; want empty", got)
+	}
+}
+
+func TestSealedSecretFormat(t *testing.T) {
+	s := ObscureSealed("hunter2")
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		if got, want := fmt.Sprintf(format, s), "camo.SealedSecret[string](REDACTED)"; got != want {
+			t.Errorf("%s: got = %q; want %q", format, got, want)
+		}
+	}
+	if got, _ := s.MarshalText(); string(got) != Redacted {
+		t.Errorf("MarshalText() = %q; want %q", got, Redacted)
+	}
+	if got := s.LogValue().String(); got != Redacted {
+		t.Errorf("LogValue() = %q; want %q", got, Redacted)
+	}
+}
+
+func TestPanicOnZeroSealedSecret(t *testing.T) {
+	var zero SealedSecret[string]
+	funcs := map[string]func(){
+		"Reveal":       func() { zero.Reveal() },
+		"AppendTo":     func() { zero.AppendTo(nil) },
+		"WithRevealed": func() { zero.WithRevealed(func(string) {}) },
+		"Unseal":       func() { zero.Unseal() },
+		"Seal":         func() { Secret[string]{}.Seal() },
+	}
+	for name, f := range funcs {
+		if _, ok := capturePanic(f); !ok {
+			t.Errorf("expected %s to panic", name)
+		}
+	}
+}
+
+func TestSealedSecretKeepsPolicy(t *testing.T) {
+	var events []CanaryEvent
+	s := Canary("hunter2", func(e CanaryEvent) { events = append(events, e) }, Labeled("db")).Seal()
+	if len(events) != 0 {
+		t.Fatalf("expected Seal() not to trigger the canary")
+	}
+	s.Reveal()
+	s.AppendTo(nil)
+	s.WithRevealed(func(string) {})
+	u := s.Unseal()
+	if len(events) != 3 {
+		t.Fatalf("got %d events; want 3", len(events))
+	}
+	for i, op := range []string{"Reveal", "AppendTo", "WithRevealed"} {
+		if e := events[i]; e.Op != op || e.Label != "db" || filepath.Base(e.File) != "sealed_test.go" {
+			t.Errorf("events[%d] = %s of %q from %s; want %s of %q from sealed_test.go", i, e.Op, e.Label, e.File, op, "db")
+		}
+	}
+	if !u.IsCanary() || u.Label() != "db" {
+		t.Errorf("expected Unseal() to keep the label and policies")
+	}
+
+	var violations []PolicyViolation
+	SetPolicyViolationHandler(func(v PolicyViolation) { violations = append(violations, v) })
+	defer SetPolicyViolationHandler(nil)
+	Obscure("hunter2", AllowCallers("example.com/app")).Seal().Unseal().Reveal()
+	if len(violations) != 1 {
+		t.Errorf("got %d violations; want 1", len(violations))
+	}
+}
@@ -8,7 +8,14 @@ import (
 	"bytes"
 	"fmt"
 	"hash/maphash"
+	"io"
+	"runtime"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Obscurable is the set of types that can be obscured by the Secret type.
@@ -51,21 +58,158 @@ type secret struct {
 	// won't stumble across this field, commonly used packages like go-spew
 	// use various hacks to peer into unexported fields, which this will
 	// thwart.
+	//
+	// It points to a box.
 	p    unsafe.Pointer
 	hash uint64
 }
 
+// box holds the content of a Secret. The content is always stored as a
+// string regardless of O, as it is immutable.
+//
+// The memory backing content is owned by the box, and is wiped or released
+// once the box becomes unreachable. It must never be aliased by anything that
+// escapes this package, and the Secret must be kept alive (see
+// runtime.KeepAlive) while the content is in use.
+type box struct {
+	content string
+
+	// locked is set when the memory backing content is locked into RAM.
+	locked bool
+
+	policy
+}
+
+// policy holds the attributes of a secret that are carried over to the
+// secrets derived from its content, such as by Map.
+type policy struct {
+	// label is set by the Labeled option.
+	label string
+
+	// canary is set for secrets created by Canary.
+	canary func(CanaryEvent)
+
+	// allowed is set by the AllowCallers option.
+	allowed []string
+
+	// hash is set by options such as KeyedHash, and is nil for the default
+	// hash.
+	hash func(string) uint64
+}
+
 // Obscure returns a Secret that wraps the given content. The content must be a
-// string or byte slice. If a byte slice is given it will be copied into a
-// newly allocated byte slice owned by the Secret.
-func Obscure[O Obscurable](content O) Secret[O] {
+// string or byte slice. The content is copied into a newly allocated buffer
+// owned by the Secret, which is wiped once the Secret is no longer reachable,
+// bounding how long the content lingers in freed memory.
+func Obscure[O Obscurable](content O, opts ...Option) Secret[O] {
 	// Make a copy to force immutability. This also means that Secrets with
 	// empty content will look like a pointer to a valid object, to avoid
 	// being able to distinguish empty secrets in any emitted output.
-	str := string(content)
+	buf := make([]byte, len(content))
+	copy(buf, content)
+	return obscureOwned[O](buf, opts...)
+}
+
+// ObscureOwned is like Obscure, but takes ownership of buf as the content
+// of the Secret without copying it, which avoids doubling the peak memory
+// used by large key blobs. The caller must not use buf, or any other slice
+// sharing its backing array, afterwards. The memory is wiped once the Secret
+// is no longer reachable. If the options require the content to be moved
+// elsewhere, such as with Guarded, buf is wiped right away instead.
+func ObscureOwned(buf []byte, opts ...Option) Secret[[]byte] {
+	return obscureOwned[[]byte](buf, opts...)
+}
+
+// obscureOwned returns a Secret that takes ownership of buf as its content
+// without copying it, unless the options require the content to be moved
+// elsewhere, in which case buf is wiped. The caller must not use buf
+// afterwards. The content is wiped once the Secret is no longer reachable.
+func obscureOwned[O Obscurable](buf []byte, opts ...Option) Secret[O] {
+	var o options
+	if len(opts) > 0 {
+		o = makeOptions(opts)
+		if o.trimmed {
+			buf = trimTrailingSpace(buf)
+		}
+		if o.normalized {
+			buf = normalize(buf, o.form)
+		}
+		if o.guarded {
+			if s, ok := obscureGuarded[O](buf, o); ok {
+				wipe(buf)
+				return s
+			}
+		}
+	}
+	b := &box{
+		content: unsafe.String(unsafe.SliceData(buf), len(buf)),
+		policy:  o.policy,
+	}
+	if len(buf) > 0 {
+		runtime.AddCleanup(b, wipe, buf)
+	}
+	return newSecret[O](b)
+}
+
+// contentView returns content as a byte slice without copying it. The
+// returned slice must never be modified.
+func contentView[O Obscurable](content O) []byte {
+	switch c := any(content).(type) {
+	case string:
+		return unsafe.Slice(unsafe.StringData(c), len(c))
+	case []byte:
+		return c
+	default:
+		panic(fmt.Sprintf("illegal type %T", c))
+	}
+}
+
+// normalize returns buf in the normalization form f, wiping buf if the
+// normalized content is stored elsewhere.
+func normalize(buf []byte, f norm.Form) []byte {
+	if f.IsNormal(buf) {
+		return buf
+	}
+	// Transform is used instead of Append so that every buffer that is
+	// outgrown can be wiped.
+	for size := len(buf) + len(buf)/2 + utf8.UTFMax; ; size *= 2 {
+		dst := make([]byte, size)
+		n, _, err := f.Transform(dst, buf, true)
+		if err == nil {
+			wipe(buf)
+			return dst[:n]
+		}
+		wipe(dst)
+		if err != transform.ErrShortDst {
+			return buf
+		}
+	}
+}
+
+// wipe zeroes buf.
+func wipe(buf []byte) {
+	clear(buf)
+}
+
+// hashContent is the hash function used for the content of Secrets unless
+// an option selects another one.
+func hashContent(content string) uint64 {
+	if hash := seededHash.Load(); hash != nil {
+		return (*hash)(content)
+	}
+	return maphash.String(hashSeed, content)
+}
+
+// newSecret returns a Secret for b, with the hash of its content computed by
+// b.hash, or by hashContent if it is nil.
+func newSecret[O Obscurable](b *box) Secret[O] {
+	hash := b.hash
+	if hash == nil {
+		hash = hashContent
+	}
 	s := secret{
-		p:    unsafe.Pointer(&str),
-		hash: maphash.String(hashSeed, str),
+		p:    unsafe.Pointer(b),
+		hash: hash(b.content),
 	}
 	return *(*Secret[O])(unsafe.Pointer(&s))
 }
@@ -76,26 +220,114 @@ func (s Secret[O]) Valid() bool {
 	return ss.p != nil
 }
 
+// Len returns the length of the content of the secret in bytes, or 0 if it
+// is zero, so that its content can be validated without revealing it, such as
+// to require a minimum length for a password. The length is information about
+// the content as well, and should only be used where exposing it is
+// acceptable.
+func (s Secret[O]) Len() int {
+	if !s.Valid() {
+		return 0
+	}
+	return len(s.str())
+}
+
+// IsEmpty reports if the secret is zero or its content is empty.
+func (s Secret[O]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Hash64 returns the hash of the content of the secret that Secrets are
+// compared by, so that third-party hash maps, sharded caches, and consistent
+// hashing can key on secrets without revealing them. It returns 0 if the
+// secret is zero. The hash is seeded randomly for each process, so it must
+// not be stored or sent to other processes, unless the secret was created
+// with KeyedHash or SipHashed.
+func (s Secret[O]) Hash64() uint64 {
+	return s.secret().hash
+}
+
+// AsBytes returns a Secret[[]byte] with the same content as s, without
+// copying or revealing it. It shares the options of s, such as its label and
+// caller policy, and is equal to any Secret[[]byte] with the same content.
+// It returns a zero Secret if s is zero.
+func (s Secret[O]) AsBytes() Secret[[]byte] {
+	return convert[[]byte](s)
+}
+
+// AsString returns a Secret[string] with the same content as s, in the same
+// way as AsBytes.
+func (s Secret[O]) AsString() Secret[string] {
+	return convert[string](s)
+}
+
 func (s Secret[O]) secret() secret {
 	return *(*secret)(unsafe.Pointer(&s))
 }
 
+func (s Secret[O]) box() *box {
+	return (*box)(s.secret().p)
+}
+
+// str returns the underlying content without copying it. It must not be
+// called on a zero secret. The returned string must not be retained, and s
+// must be kept alive until the caller is done with it.
+func (s Secret[O]) str() string {
+	return s.box().content
+}
+
+// view returns the underlying content as a byte slice without copying it. The
+// returned slice aliases immutable memory and must never be modified. The
+// same restrictions as str apply.
+func (s Secret[O]) view() []byte {
+	str := s.str()
+	return unsafe.Slice(unsafe.StringData(str), len(str))
+}
+
+// deref returns the underlying content as an O without copying it. The same
+// restrictions as view apply.
 func (s Secret[O]) deref() O {
-	ss := s.secret()
-	return *(*O)(ss.p)
+	var content O
+	switch p := any(&content).(type) {
+	case *string:
+		*p = s.str()
+	case *[]byte:
+		*p = s.view()
+	}
+	return content
 }
 
 // Reveal returns the underlying secret data. If the secret is a byte slice,
 // then a copy of the byte slice is returned. If the secret is a string, then
-// the string is returned. It panics if the secret is zero.
+// a copy of the string is returned, as the memory holding the content is
+// wiped once the Secret is no longer reachable. It panics if the secret is
+// zero.
 func (s Secret[O]) Reveal() O {
 	ss := s.secret()
 	if ss.p == nil {
-		panic("illegal use of Reveal on a zero secret")
+		panicZero("Reveal")
 	}
+	defer runtime.KeepAlive(s)
+	s.revealed("Reveal")
+	return s.clone()
+}
+
+// RevealOr is like Reveal, but returns def if the secret is zero, such as for
+// an optional credential that wasn't configured.
+func (s Secret[O]) RevealOr(def O) O {
+	if !s.Valid() {
+		return def
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("RevealOr")
+	return s.clone()
+}
+
+// clone returns a copy of the content of the secret, which must not be zero.
+func (s Secret[O]) clone() O {
 	switch v := any(s.deref()).(type) {
 	case string:
-		return O(v)
+		return O(strings.Clone(v))
 	case []byte:
 		return O(bytes.Clone(v))
 	default:
@@ -103,12 +335,57 @@ func (s Secret[O]) Reveal() O {
 	}
 }
 
+// FirstValid returns the first of secrets that isn't zero, or a zero Secret
+// if there is none, so that an override can take precedence over a default,
+// as in FirstValid(flagToken, envToken, fileToken).
+func FirstValid[O Obscurable](secrets ...Secret[O]) Secret[O] {
+	for _, s := range secrets {
+		if s.Valid() {
+			return s
+		}
+	}
+	return Secret[O]{}
+}
+
 // AppendTo appends the secret to the byte slice, and returns the updated
 // slice. It panics if the secret is zero.
 func (s Secret[O]) AppendTo(dst []byte) []byte {
 	ss := s.secret()
 	if ss.p == nil {
-		panic("illegal use of AppendTo on a zero secret")
+		panicZero("AppendTo")
 	}
+	defer runtime.KeepAlive(s)
+	s.revealed("AppendTo")
 	return append(dst, s.deref()...)
 }
+
+// RevealInto copies the secret into dst, and returns the number of bytes
+// copied, which is the smaller of len(dst) and Len. It avoids allocating a
+// copy of the content, and dst can be wiped by the caller once it is done
+// with it, which makes it suitable for hot paths. It panics if the secret is
+// zero.
+func (s Secret[O]) RevealInto(dst []byte) int {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("RevealInto")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("RevealInto")
+	return copy(dst, s.str())
+}
+
+// WriteTo implements io.WriterTo, writing the secret to w, so that it can be
+// streamed into a connection or request body without the caller holding a
+// copy of it. w is given the memory holding the content, which, as required
+// by io.Writer, it must not modify or retain. It panics if the secret is
+// zero.
+func (s Secret[O]) WriteTo(w io.Writer) (int64, error) {
+	ss := s.secret()
+	if ss.p == nil {
+		panicZero("WriteTo")
+	}
+	defer runtime.KeepAlive(s)
+	s.revealed("WriteTo")
+	n, err := w.Write(s.view())
+	return int64(n), err
+}
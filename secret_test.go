@@ -2,9 +2,16 @@ package camo
 
 import (
 	"bytes"
+	"io"
+	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+	"unsafe"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 func FuzzSecret(f *testing.F) {
@@ -149,6 +156,227 @@ func TestAppendTo(t *testing.T) {
 	}
 }
 
+func TestRevealInto(t *testing.T) {
+	s := Obscure([]byte("hunter2"))
+	buf := make([]byte, 16)
+	n := s.RevealInto(buf)
+	if got := buf[:n]; string(got) != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if n := s.RevealInto(buf[:3]); n != 3 || string(buf[:n]) != "hun" {
+		t.Errorf("got = %q; want %q", buf[:n], "hun")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { s.RevealInto(buf) }); allocs != 0 {
+		t.Errorf("got %v allocations; want 0", allocs)
+	}
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.RevealInto(buf) }); !ok {
+		t.Errorf("expected zero.RevealInto() to panic")
+	}
+}
+
+func TestRevealOr(t *testing.T) {
+	var zero Secret[string]
+	if got := zero.RevealOr("default"); got != "default" {
+		t.Errorf("got = %q; want %q", got, "default")
+	}
+	if got := Obscure("hunter2").RevealOr("default"); got != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if got := Obscure([]byte{}).RevealOr([]byte("default")); len(got) != 0 {
+		t.Errorf("got = %q; want empty", got)
+	}
+}
+
+func TestFirstValid(t *testing.T) {
+	var zero Secret[string]
+	if got := FirstValid[string](); got.Valid() {
+		t.Errorf("FirstValid() should be zero")
+	}
+	if got := FirstValid(zero, zero); got.Valid() {
+		t.Errorf("FirstValid(zero, zero) should be zero")
+	}
+	a, b := Obscure("a"), Obscure("b")
+	if got := FirstValid(zero, a, b); got != a {
+		t.Errorf("got = %v; want the first valid secret", got)
+	}
+	if got := FirstValid(Obscure(""), a); got.Reveal() != "" {
+		t.Errorf("empty secrets should count as valid")
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := Obscure("hunter2").WriteTo(&buf)
+	if err != nil || n != 7 || buf.String() != "hunter2" {
+		t.Errorf("got = %d, %v, %q; want 7, nil, %q", n, err, buf.String(), "hunter2")
+	}
+
+	var _ io.WriterTo = Secret[[]byte]{}
+	pr, pw := io.Pipe()
+	pr.CloseWithError(io.ErrClosedPipe)
+	if _, err := Obscure([]byte("hunter2")).WriteTo(pw); err != io.ErrClosedPipe {
+		t.Errorf("got err = %v; want %v", err, io.ErrClosedPipe)
+	}
+
+	var zero Secret[string]
+	if _, ok := capturePanic(func() { zero.WriteTo(&buf) }); !ok {
+		t.Errorf("expected zero.WriteTo() to panic")
+	}
+}
+
+func TestLen(t *testing.T) {
+	tests := []struct {
+		name  string
+		len   int
+		empty bool
+		s     interface {
+			Len() int
+			IsEmpty() bool
+		}
+	}{
+		{"zero", 0, true, Secret[string]{}},
+		{"empty", 0, true, Obscure("")},
+		{"string", 7, false, Obscure("hunter2")},
+		{"bytes", 3, false, Obscure([]byte{0, 1, 2})},
+		{"guarded", 7, false, Obscure("hunter2", Guarded())},
+	}
+	for _, tc := range tests {
+		if got := tc.s.Len(); got != tc.len {
+			t.Errorf("%s: Len() = %d; want %d", tc.name, got, tc.len)
+		}
+		if got := tc.s.IsEmpty(); got != tc.empty {
+			t.Errorf("%s: IsEmpty() = %v; want %v", tc.name, got, tc.empty)
+		}
+	}
+}
+
+func TestHash64(t *testing.T) {
+	if Obscure("hunter2").Hash64() != Obscure([]byte("hunter2")).Hash64() {
+		t.Errorf("expected the same hash for the same content")
+	}
+	if Obscure("hunter2").Hash64() == Obscure("hunter3").Hash64() {
+		t.Errorf("expected different hashes for different content")
+	}
+	if got := (Secret[string]{}).Hash64(); got != 0 {
+		t.Errorf("got = %d for a zero secret; want 0", got)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	s := Obscure("hunter2", Labeled("password"))
+	b := s.AsBytes()
+	if b != Obscure([]byte("hunter2")) {
+		t.Errorf("expected AsBytes() to equal a Secret[[]byte] with the same content")
+	}
+	if got := b.Reveal(); string(got) != "hunter2" {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+	if b.Label() != "password" {
+		t.Errorf("expected AsBytes() to keep the label")
+	}
+	if b.AsString() != s || s.AsString() != s {
+		t.Errorf("expected AsString() to equal the original Secret")
+	}
+	if (Secret[string]{}).AsBytes().Valid() || (Secret[[]byte]{}).AsString().Valid() {
+		t.Errorf("expected conversions of zero Secrets to be zero")
+	}
+}
+
+func TestObscureTrimmed(t *testing.T) {
+	if got := Obscure("hunter2\r\n", Trimmed()); got != Obscure("hunter2") {
+		t.Errorf("got = %q; want %q", got.Reveal(), "hunter2")
+	}
+	if got := Obscure([]byte("hunter2 \t\u00a0\n"), Trimmed()); got != Obscure([]byte("hunter2")) {
+		t.Errorf("got = %q; want %q", got.Reveal(), "hunter2")
+	}
+	if got := Obscure(" hunter2\n", Trimmed(), Guarded()); got != Obscure(" hunter2") {
+		t.Errorf("got = %q; want %q", got.Reveal(), " hunter2")
+	}
+	if got := Obscure("hunter2\n"); got != Obscure("hunter2\n") || got == Obscure("hunter2") {
+		t.Errorf("expected content to be kept as is without Trimmed")
+	}
+}
+
+func TestObscureNormalized(t *testing.T) {
+	composed, decomposed := "p\u00e4ss", "pa\u0308ss"
+	if got := Obscure(decomposed, NormalizedNFC()); got != Obscure(composed) {
+		t.Errorf("got = %q; want %q", got.Reveal(), composed)
+	}
+	if got := Obscure([]byte(composed), NormalizedNFC()); got != Obscure([]byte(composed)) {
+		t.Errorf("got = %q; want %q", got.Reveal(), composed)
+	}
+	if got := Obscure("\uff50\uff41\uff53\uff53", NormalizedNFKC()); got != Obscure("pass") {
+		t.Errorf("got = %q; want %q", got.Reveal(), "pass")
+	}
+	if got := Obscure("\uff50\uff41\uff53\uff53", NormalizedNFC()); got == Obscure("pass") {
+		t.Errorf("expected NFC to keep compatibility characters")
+	}
+	if got := Obscure(decomposed+"\n", Trimmed(), NormalizedNFC(), Guarded()); got != Obscure(composed) {
+		t.Errorf("got = %q; want %q", got.Reveal(), composed)
+	}
+	if got := Obscure("\xff\xfe", NormalizedNFC()); got != Obscure("\xff\xfe") {
+		t.Errorf("expected invalid UTF-8 to be kept as is, got %q", got.Reveal())
+	}
+	if got := Obscure(decomposed); got == Obscure(composed) {
+		t.Errorf("expected content to be kept as is without normalization")
+	}
+}
+
+func TestNormalizeGrows(t *testing.T) {
+	// U+FDFA expands to 18 runes under NFKC.
+	in := strings.Repeat("\ufdfa", 10)
+	want := norm.NFKC.String(in)
+	if got := normalize([]byte(in), norm.NFKC); string(got) != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
+
+func TestObscureOwned(t *testing.T) {
+	buf := []byte("hunter2")
+	s := ObscureOwned(buf)
+	if s != Obscure([]byte("hunter2")) {
+		t.Errorf("got = %q; want %q", s.Reveal(), "hunter2")
+	}
+	if unsafe.SliceData(s.view()) != unsafe.SliceData(buf) {
+		t.Errorf("expected ObscureOwned to not copy the content")
+	}
+
+	buf = []byte("hunter2")
+	s = ObscureOwned(buf, Guarded())
+	if string(s.Reveal()) != "hunter2" {
+		t.Errorf("got = %q; want %q", s.Reveal(), "hunter2")
+	}
+	moved := unsafe.SliceData(s.view()) != unsafe.SliceData(buf)
+	if moved && !bytes.Equal(buf, make([]byte, len(buf))) {
+		t.Errorf("expected buf to be wiped once moved, got %q", buf)
+	}
+}
+
+func TestObscureWipesOnGC(t *testing.T) {
+	// Holding on to the backing memory keeps it from being reclaimed, so the
+	// effect of the cleanup can be observed.
+	backing := Obscure([]byte("hunter2")).view()
+	deadline := time.Now().Add(5 * time.Second)
+	for !bytes.Equal(backing, make([]byte, len("hunter2"))) {
+		if time.Now().After(deadline) {
+			t.Fatalf("backing memory was not wiped, got %q", backing)
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRevealOutlivesSecret(t *testing.T) {
+	revealed := Obscure("hunter2").Reveal()
+	for range 3 {
+		runtime.GC()
+	}
+	if revealed != "hunter2" {
+		t.Errorf("revealed = %q; want %q", revealed, "hunter2")
+	}
+}
+
 // capturePanic executes f and if it panics, it return the value passed to
 // panic and true, otherwise it returns a nil value and false.
 func capturePanic(f func()) (any, bool) {
@@ -0,0 +1,113 @@
+package camo
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+)
+
+// Codec encodes and decodes the values held by a SecretOf. Decode must not
+// modify or retain data.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(data []byte, v *T) error
+}
+
+// JSONCodec is a Codec using encoding/json.
+type JSONCodec[T any] struct{}
+
+// Encode implements Codec.
+func (JSONCodec[T]) Encode(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode implements Codec.
+func (JSONCodec[T]) Decode(data []byte, v *T) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobCodec is a Codec using encoding/gob.
+type GobCodec[T any] struct{}
+
+// Encode implements Codec.
+func (GobCodec[T]) Encode(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode implements Codec.
+func (GobCodec[T]) Decode(data []byte, v *T) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// SecretOf holds a structured value, such as a service account key or a
+// key pair, encoded with a Codec in a Secret, so that it can be obscured as a
+// unit instead of field by field. Like Secret, it is immutable, and its zero
+// value is distinct from a secret holding a zero value of T.
+type SecretOf[T any] struct {
+	s     Secret[[]byte]
+	codec Codec[T]
+}
+
+// ObscureOf returns a SecretOf holding v encoded with codec. The encoded
+// form is obscured without being copied. Buffers used internally by the
+// codec aren't wiped.
+func ObscureOf[T any](v T, codec Codec[T], opts ...Option) (SecretOf[T], error) {
+	data, err := codec.Encode(v)
+	if err != nil {
+		return SecretOf[T]{}, err
+	}
+	return SecretOf[T]{s: ObscureOwned(data, opts...), codec: codec}, nil
+}
+
+// Valid reports if the SecretOf is valid.
+func (s SecretOf[T]) Valid() bool {
+	return s.s.Valid()
+}
+
+// Reveal returns the value held by s, decoded with its codec. It panics if s
+// is zero.
+func (s SecretOf[T]) Reveal() (T, error) {
+	var v T
+	if !s.s.Valid() {
+		panicZero("Reveal")
+	}
+	defer runtime.KeepAlive(s.s)
+	s.s.revealed("Reveal")
+	err := s.codec.Decode(s.s.view(), &v)
+	return v, err
+}
+
+// String implements fmt.Stringer, returning a redacted representation that
+// includes the type of the value, such as "camo.SecretOf[main.Key](REDACTED)".
+func (s SecretOf[T]) String() string {
+	return "camo.SecretOf[" + reflect.TypeFor[T]().String() + "](" + Redacted + ")"
+}
+
+// GoString implements fmt.GoStringer, returning the same as String.
+func (s SecretOf[T]) GoString() string {
+	return s.String()
+}
+
+// Format implements fmt.Formatter, so that every verb prints the redacted
+// representation.
+func (s SecretOf[T]) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), s.String())
+}
+
+// LogValue implements slog.LogValuer, rendering as Redacted.
+func (s SecretOf[T]) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// MarshalText implements encoding.TextMarshaler, always returning Redacted.
+func (s SecretOf[T]) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
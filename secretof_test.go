@@ -0,0 +1,66 @@
+package camo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type keyPair struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func TestSecretOf(t *testing.T) {
+	want := keyPair{AccessKeyID: "AKIA123", SecretAccessKey: "hunter2"}
+	for name, codec := range map[string]Codec[keyPair]{
+		"json": JSONCodec[keyPair]{},
+		"gob":  GobCodec[keyPair]{},
+	} {
+		s, err := ObscureOf(want, codec)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !s.Valid() {
+			t.Errorf("%s: expected a valid SecretOf", name)
+		}
+		got, err := s.Reveal()
+		if err != nil || got != want {
+			t.Errorf("%s: got = %+v, %v; want %+v", name, got, err, want)
+		}
+	}
+}
+
+func TestSecretOfRedacts(t *testing.T) {
+	s, err := ObscureOf(keyPair{SecretAccessKey: "hunter2"}, JSONCodec[keyPair]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "camo.SecretOf[camo.keyPair](REDACTED)"
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		if got := fmt.Sprintf(format, s); !strings.Contains(got, want) {
+			t.Errorf("Sprintf(%q) = %q; want %q", format, got, want)
+		}
+	}
+	b, err := json.Marshal(struct{ Key SecretOf[keyPair] }{s})
+	if err != nil || string(b) != `{"Key":"REDACTED"}` {
+		t.Errorf("got = %s, %v", b, err)
+	}
+}
+
+func TestSecretOfZero(t *testing.T) {
+	var zero SecretOf[keyPair]
+	if zero.Valid() {
+		t.Errorf("expected a zero SecretOf to be invalid")
+	}
+	if _, ok := capturePanic(func() { zero.Reveal() }); !ok {
+		t.Errorf("expected zero.Reveal() to panic")
+	}
+}
+
+func TestObscureOfError(t *testing.T) {
+	if _, err := ObscureOf(func() {}, JSONCodec[func()]{}); err == nil {
+		t.Errorf("expected an error encoding a func")
+	}
+}
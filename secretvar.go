@@ -0,0 +1,34 @@
+package camo
+
+import "sync/atomic"
+
+// SecretVar holds a Secret that can be replaced while it is being read, such
+// as a credential used by a long-lived connection pool that is updated by a
+// rotation goroutine.
+//
+// The zero value holds the zero Secret. A SecretVar must not be copied after
+// first use. It is safe for concurrent use.
+type SecretVar[O Obscurable] struct {
+	p atomic.Pointer[Secret[O]]
+}
+
+// Load returns the current Secret.
+func (v *SecretVar[O]) Load() Secret[O] {
+	if p := v.p.Load(); p != nil {
+		return *p
+	}
+	return Secret[O]{}
+}
+
+// Store replaces the current Secret with s.
+func (v *SecretVar[O]) Store(s Secret[O]) {
+	v.p.Store(&s)
+}
+
+// Swap replaces the current Secret with s and returns the previous one.
+func (v *SecretVar[O]) Swap(s Secret[O]) (old Secret[O]) {
+	if p := v.p.Swap(&s); p != nil {
+		return *p
+	}
+	return Secret[O]{}
+}
@@ -0,0 +1,48 @@
+package camo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSecretVar(t *testing.T) {
+	var v SecretVar[string]
+	if v.Load().Valid() {
+		t.Errorf("expected the zero SecretVar to hold the zero Secret")
+	}
+	v.Store(Obscure("v1"))
+	if got := v.Load().Reveal(); got != "v1" {
+		t.Errorf("Load() = %q; want %q", got, "v1")
+	}
+	if old := v.Swap(Obscure("v2")); old != Obscure("v1") {
+		t.Errorf("Swap() = %q; want %q", old.Reveal(), "v1")
+	}
+	if got := v.Load().Reveal(); got != "v2" {
+		t.Errorf("Load() = %q; want %q", got, "v2")
+	}
+}
+
+func TestSecretVarConcurrent(t *testing.T) {
+	var v SecretVar[[]byte]
+	v.Store(Obscure([]byte("v0")))
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				v.Store(Obscure([]byte("v1")))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if !v.Load().Valid() {
+					t.Error("Load() returned the zero Secret")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
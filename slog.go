@@ -0,0 +1,74 @@
+package camo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer, so that a Secret logged as an attribute
+// renders as Redacted.
+func (s Secret[O]) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// NewScrubHandler returns a slog.Handler that scrubs the content of
+// registered secrets (see Register) from the message and attribute values of
+// every record before passing it on to h.
+//
+// Values of kind slog.KindAny are formatted with fmt to check them for
+// registered content, and are replaced with their scrubbed string form if
+// any is found.
+func NewScrubHandler(h slog.Handler) slog.Handler {
+	return &scrubHandler{h: h}
+}
+
+type scrubHandler struct {
+	h slog.Handler
+}
+
+func (h *scrubHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+func (h *scrubHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, Scrub(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(scrubAttr(a))
+		return true
+	})
+	return h.h.Handle(ctx, nr)
+}
+
+func (h *scrubHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	scrubbed := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		scrubbed[i] = scrubAttr(a)
+	}
+	return &scrubHandler{h: h.h.WithAttrs(scrubbed)}
+}
+
+func (h *scrubHandler) WithGroup(name string) slog.Handler {
+	return &scrubHandler{h: h.h.WithGroup(name)}
+}
+
+func scrubAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		v = slog.StringValue(Scrub(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		scrubbed := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			scrubbed[i] = scrubAttr(ga)
+		}
+		v = slog.GroupValue(scrubbed...)
+	case slog.KindAny:
+		str := fmt.Sprintf("%+v", v.Any())
+		if scrubbed := Scrub(str); scrubbed != str {
+			v = slog.StringValue(scrubbed)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
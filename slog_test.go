@@ -0,0 +1,61 @@
+package camo
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("login", "password", Obscure("hunter2"), slog.Group("db", "key", Obscure([]byte("hunter2"))))
+
+	got := buf.String()
+	want := "level=INFO msg=login password=REDACTED db.key=REDACTED\n"
+	if got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+	if strings.Contains(got, "hunter2") {
+		t.Errorf("log output contains secret content")
+	}
+}
+
+func TestScrubHandler(t *testing.T) {
+	s := Obscure("hunter2")
+	Register(s)
+	t.Cleanup(func() { Unregister(s) })
+
+	var buf bytes.Buffer
+	logger := slog.New(NewScrubHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	logger = logger.With("dsn", "postgres://bob:hunter2@db")
+	logger.WithGroup("req").Info(
+		"logging in with hunter2",
+		"password", s.Reveal(),
+		"err", errors.New("bad password hunter2"),
+		"count", 2,
+		slog.Group("nested", "token", "xhunter2x"),
+	)
+
+	got := buf.String()
+	want := `level=INFO msg="logging in with ***" dsn=postgres://bob:***@db req.password=*** req.err="bad password ***" req.count=2 req.nested.token=x***x` + "\n"
+	if got != want {
+		t.Errorf("got = %q; want %q", got, want)
+	}
+}
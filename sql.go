@@ -0,0 +1,43 @@
+package camo
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner, so that Secrets can be scanned directly out of
+// database rows. A NULL value scans as a zero Secret.
+func (s *Secret[O]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = Secret[O]{}
+	case string:
+		*s = Obscure(O(v))
+	case []byte:
+		*s = Obscure(O(v))
+	default:
+		return fmt.Errorf("camo: cannot scan %T into Secret", src)
+	}
+	return nil
+}
+
+// SQLValue returns a driver.Valuer that passes the secret content to a
+// database as a query parameter. A zero Secret is passed as NULL.
+//
+// Secret intentionally does not implement driver.Valuer itself, so passing a
+// Secret as a query argument without calling SQLValue fails rather than
+// sending the content to the database by accident.
+func (s Secret[O]) SQLValue() driver.Valuer {
+	return sqlValue[O]{s: s}
+}
+
+type sqlValue[O Obscurable] struct {
+	s Secret[O]
+}
+
+func (v sqlValue[O]) Value() (driver.Value, error) {
+	if !v.s.Valid() {
+		return nil, nil
+	}
+	return v.s.Reveal(), nil
+}
@@ -0,0 +1,83 @@
+package camo
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	cases := []struct {
+		name string
+		src  any
+		want string
+	}{
+		{name: "string", src: "hunter2", want: "hunter2"},
+		{name: "bytes", src: []byte("hunter2"), want: "hunter2"},
+		{name: "empty", src: "", want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s Secret[string]
+			if err := s.Scan(tc.src); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := s.Reveal(); got != tc.want {
+				t.Errorf("got = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScanCopiesBytes(t *testing.T) {
+	src := []byte("hunter2")
+	var s Secret[[]byte]
+	if err := s.Scan(src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src[0] = 'H'
+	if got := s.Reveal(); !bytes.Equal(got, []byte("hunter2")) {
+		t.Errorf("got = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestScanNull(t *testing.T) {
+	s := Obscure("hunter2")
+	if err := s.Scan(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Valid() {
+		t.Errorf("expected NULL to scan as a zero Secret")
+	}
+}
+
+func TestScanUnsupported(t *testing.T) {
+	var s Secret[string]
+	if err := s.Scan(int64(1)); err == nil {
+		t.Errorf("expected error scanning int64")
+	}
+}
+
+func TestSQLValue(t *testing.T) {
+	got, err := Obscure("hunter2").SQLValue().Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "hunter2" {
+		t.Errorf("got = %v; want %q", got, "hunter2")
+	}
+
+	got, err = Secret[[]byte]{}.SQLValue().Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("got = %v; want nil", got)
+	}
+}
+
+func TestSecretIsNotAValidDriverValue(t *testing.T) {
+	if _, err := driver.DefaultParameterConverter.ConvertValue(Obscure("hunter2")); err == nil {
+		t.Errorf("expected Secret to be rejected as a query parameter")
+	}
+}
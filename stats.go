@@ -0,0 +1,61 @@
+package camo
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Stats holds process-wide counters of how secrets are used, so that
+// unexpected spikes in access to secrets can be noticed. See the camoexpvar
+// and camoprom packages for exporting them.
+type Stats struct {
+	// Reveals is the number of times the content of a secret was extracted
+	// with Reveal or a similar method that returns a copy of it.
+	Reveals uint64
+
+	// Appends is the number of times the content of a secret was appended
+	// to a byte slice with AppendTo or a similar method.
+	Appends uint64
+
+	// ZeroPanics is the number of panics caused by using the content of a
+	// zero secret.
+	ZeroPanics uint64
+
+	// ScrubHits is the number of times registered content was found and
+	// scrubbed, counted once per call to Scrub that changed its input, and
+	// once per occurrence written to a ScrubWriter.
+	ScrubHits uint64
+}
+
+var stats struct {
+	reveals    atomic.Uint64
+	appends    atomic.Uint64
+	zeroPanics atomic.Uint64
+	scrubHits  atomic.Uint64
+}
+
+// ReadStats returns the current values of the counters.
+func ReadStats() Stats {
+	return Stats{
+		Reveals:    stats.reveals.Load(),
+		Appends:    stats.appends.Load(),
+		ZeroPanics: stats.zeroPanics.Load(),
+		ScrubHits:  stats.scrubHits.Load(),
+	}
+}
+
+// countReveal counts the extraction of the content of a secret by the method
+// named op.
+func countReveal(op string) {
+	if strings.HasPrefix(op, "Append") {
+		stats.appends.Add(1)
+	} else {
+		stats.reveals.Add(1)
+	}
+}
+
+// panicZero panics for the use of the method named op on a zero secret.
+func panicZero(op string) {
+	stats.zeroPanics.Add(1)
+	panic("illegal use of " + op + " on a zero secret")
+}
@@ -0,0 +1,36 @@
+package camo
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	before := ReadStats()
+
+	s := Obscure("camo-test-stats")
+	s.Reveal()
+	s.AppendTo(nil)
+	func() {
+		defer func() { recover() }()
+		Secret[string]{}.Reveal()
+	}()
+	Register(s)
+	defer Unregister(s)
+	Scrub("token camo-test-stats")
+	var buf bytes.Buffer
+	w := NewScrubWriter(&buf)
+	w.Write([]byte("camo-test-stats camo-test-stats\n"))
+	w.Flush()
+
+	after := ReadStats()
+	want := Stats{
+		Reveals:    before.Reveals + 1,
+		Appends:    before.Appends + 1,
+		ZeroPanics: before.ZeroPanics + 1,
+		ScrubHits:  before.ScrubHits + 3,
+	}
+	if after != want {
+		t.Errorf("ReadStats() = %+v; want %+v", after, want)
+	}
+}
@@ -0,0 +1,98 @@
+package camo
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+)
+
+// Alphabets for use with GenerateToken.
+const (
+	AlphabetAlphanumeric      = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	AlphabetLowerAlphanumeric = "0123456789abcdefghijklmnopqrstuvwxyz"
+	AlphabetBase32            = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	// AlphabetCrockford is Crockford's base32 alphabet, which excludes
+	// letters that are easily confused with digits.
+	AlphabetCrockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+var errInvalidAlphabet = errors.New("camo: alphabet must have between 2 and 256 bytes")
+
+// GenerateToken returns a Secret holding a random token of length bytes, each
+// chosen uniformly from alphabet using crypto/rand. The alphabet is treated as
+// a set of bytes, so it should be ASCII.
+func GenerateToken(length int, alphabet string) (Secret[string], error) {
+	return GeneratePrefixedToken("", length, alphabet)
+}
+
+// GeneratePrefixedToken is like GenerateToken, but the token starts with
+// prefix, which is not counted in length. This is useful for issuing tokens
+// in formats like "sk_live_…", where the prefix identifies the kind of token.
+func GeneratePrefixedToken(prefix string, length int, alphabet string) (Secret[string], error) {
+	if length < 0 {
+		return Secret[string]{}, errNegativeLength
+	}
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		return Secret[string]{}, errInvalidAlphabet
+	}
+	buf := make([]byte, len(prefix)+length)
+	copy(buf, prefix)
+	if err := fillFromAlphabet(buf[len(prefix):], alphabet); err != nil {
+		wipe(buf)
+		return Secret[string]{}, err
+	}
+	return obscureOwned[string](buf), nil
+}
+
+// fillFromAlphabet fills dst with bytes chosen uniformly from alphabet. To
+// avoid modulo bias, random bytes are masked to the smallest power of two
+// that covers the alphabet, and rejected if they fall outside of it.
+func fillFromAlphabet(dst []byte, alphabet string) error {
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1)
+	var random [64]byte
+	defer wipe(random[:])
+	for i := 0; i < len(dst); {
+		if _, err := rand.Read(random[:]); err != nil {
+			return err
+		}
+		for _, r := range random {
+			if j := int(r & mask); j < len(alphabet) {
+				dst[i] = alphabet[j]
+				i++
+				if i == len(dst) {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GenerateHexToken returns a Secret holding n random bytes from crypto/rand,
+// encoded as hex.
+func GenerateHexToken(n int) (Secret[string], error) {
+	return generateEncodedToken(n, hex.EncodedLen, func(dst, src []byte) { hex.Encode(dst, src) })
+}
+
+// GenerateBase64URLToken returns a Secret holding n random bytes from
+// crypto/rand, encoded as unpadded URL-safe base64.
+func GenerateBase64URLToken(n int) (Secret[string], error) {
+	enc := base64.RawURLEncoding
+	return generateEncodedToken(n, enc.EncodedLen, enc.Encode)
+}
+
+func generateEncodedToken(n int, encodedLen func(int) int, encode func(dst, src []byte)) (Secret[string], error) {
+	if n < 0 {
+		return Secret[string]{}, errNegativeLength
+	}
+	random := make([]byte, n)
+	defer wipe(random)
+	if _, err := rand.Read(random); err != nil {
+		return Secret[string]{}, err
+	}
+	buf := make([]byte, encodedLen(n))
+	encode(buf, random)
+	return obscureOwned[string](buf), nil
+}
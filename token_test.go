@@ -0,0 +1,80 @@
+package camo
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestGenerateToken(t *testing.T) {
+	s, err := GenerateToken(40, AlphabetCrockford)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := s.Reveal()
+	if len(token) != 40 {
+		t.Errorf("len = %d; want 40", len(token))
+	}
+	for _, c := range token {
+		if !strings.ContainsRune(AlphabetCrockford, c) {
+			t.Errorf("token %q contains %q, which is not in the alphabet", token, c)
+		}
+	}
+
+	for _, alphabet := range []string{"", "a", strings.Repeat("a", 257)} {
+		if _, err := GenerateToken(8, alphabet); err == nil {
+			t.Errorf("expected an error for an alphabet of length %d", len(alphabet))
+		}
+	}
+	if _, err := GenerateToken(-1, AlphabetAlphanumeric); err == nil {
+		t.Errorf("expected an error for a negative length")
+	}
+}
+
+func TestGenerateTokenUniform(t *testing.T) {
+	// With an alphabet of 3, two thirds of the masked random bytes would be
+	// mapped onto the first letter if modulo reduction was used.
+	s, err := GenerateToken(30000, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range "abc" {
+		if n := strings.Count(s.Reveal(), string(c)); n < 9000 || n > 11000 {
+			t.Errorf("%q appeared %d times; want about 10000", c, n)
+		}
+	}
+}
+
+func TestGeneratePrefixedToken(t *testing.T) {
+	s, err := GeneratePrefixedToken("sk_live_", 24, AlphabetAlphanumeric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := s.Reveal()
+	if !strings.HasPrefix(token, "sk_live_") || len(token) != len("sk_live_")+24 {
+		t.Errorf("token = %q; want sk_live_ followed by 24 characters", token)
+	}
+}
+
+func TestGenerateHexToken(t *testing.T) {
+	s, err := GenerateHexToken(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := hex.DecodeString(s.Reveal())
+	if err != nil || len(b) != 16 {
+		t.Errorf("token %q does not decode to 16 bytes: %v", s.Reveal(), err)
+	}
+}
+
+func TestGenerateBase64URLToken(t *testing.T) {
+	s, err := GenerateBase64URLToken(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s.Reveal())
+	if err != nil || len(b) != 32 {
+		t.Errorf("token %q does not decode to 32 bytes: %v", s.Reveal(), err)
+	}
+}
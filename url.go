@@ -0,0 +1,65 @@
+package camo
+
+import "net/url"
+
+// URL is a URL whose password is held as a Secret. The password is only
+// placed into a *url.URL by Resolve, which is intended to be called at the
+// point of connecting, so that the URL can otherwise be logged and included
+// in errors freely.
+type URL struct {
+	u        url.URL
+	username string
+	password Secret[string]
+}
+
+// NewURL returns a URL that combines u with the given username and password.
+// Any userinfo present in u is discarded, and a nil u is treated as an empty
+// URL.
+func NewURL(u *url.URL, username string, password Secret[string]) URL {
+	nu := URL{
+		username: username,
+		password: password,
+	}
+	if u != nil {
+		nu.u = *u
+	}
+	nu.u.User = nil
+	return nu
+}
+
+// Resolve returns a new *url.URL that includes the username and the revealed
+// password. If the password is a zero Secret, only the username is included,
+// and if the username is empty as well, the URL has no userinfo.
+func (u URL) Resolve() *url.URL {
+	ru := u.u
+	if u.password.Valid() {
+		ru.User = url.UserPassword(u.username, u.password.Reveal())
+	} else if u.username != "" {
+		ru.User = url.User(u.username)
+	}
+	return &ru
+}
+
+// String returns the URL with its password replaced by Redacted.
+func (u URL) String() string {
+	ru := u.u
+	if u.password.Valid() {
+		ru.User = url.UserPassword(u.username, Redacted)
+	} else if u.username != "" {
+		ru.User = url.User(u.username)
+	}
+	return ru.String()
+}
+
+// RedactURL returns a copy of u that is safe to display, with the password
+// replaced by Redacted if it has one. It returns nil if u is nil.
+func RedactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+	ru := *u
+	if _, ok := ru.User.Password(); ok {
+		ru.User = url.UserPassword(ru.User.Username(), Redacted)
+	}
+	return &ru
+}
@@ -0,0 +1,66 @@
+package camo
+
+import (
+	"slices"
+	"sync"
+)
+
+// Versions holds the current version of a secret along with a number of
+// previous versions, such as signing keys, so that verification can accept
+// recently rotated-out versions while signing uses the newest.
+//
+// It is safe for concurrent use.
+type Versions[O Obscurable] struct {
+	mu       sync.RWMutex
+	previous int
+
+	// all holds the versions from newest to oldest.
+	all []Secret[O]
+}
+
+// NewVersions returns Versions holding current, which keeps up to previous
+// versions once they are replaced.
+func NewVersions[O Obscurable](current Secret[O], previous int) *Versions[O] {
+	all := make([]Secret[O], 1, 1+max(previous, 0))
+	all[0] = current
+	return &Versions[O]{
+		previous: max(previous, 0),
+		all:      all,
+	}
+}
+
+// Current returns the newest version.
+func (v *Versions[O]) Current() Secret[O] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.all[0]
+}
+
+// All returns all of the versions, from newest to oldest.
+func (v *Versions[O]) All() []Secret[O] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Clone(v.all)
+}
+
+// Rotate makes s the current version, dropping the oldest version if there
+// are more previous versions than are kept.
+func (v *Versions[O]) Rotate(s Secret[O]) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.all) > v.previous {
+		v.all = v.all[:v.previous]
+	}
+	v.all = slices.Insert(v.all, 0, s)
+}
+
+// VerifyAny calls verify with each version, from newest to oldest, and
+// reports if any of them returned true.
+func (v *Versions[O]) VerifyAny(verify func(Secret[O]) bool) bool {
+	for _, s := range v.All() {
+		if verify(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package camo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVersions(t *testing.T) {
+	v := NewVersions(Obscure("k1"), 2)
+	v.Rotate(Obscure("k2"))
+	v.Rotate(Obscure("k3"))
+	v.Rotate(Obscure("k4"))
+
+	if got := v.Current().Reveal(); got != "k4" {
+		t.Errorf("Current() = %q; want %q", got, "k4")
+	}
+	want := []Secret[string]{Obscure("k4"), Obscure("k3"), Obscure("k2")}
+	if got := v.All(); !slices.Equal(got, want) {
+		t.Errorf("All() has %d versions; want k4, k3, k2", len(got))
+	}
+
+	verify := func(key string) func(Secret[string]) bool {
+		return func(s Secret[string]) bool { return s == Obscure(key) }
+	}
+	if !v.VerifyAny(verify("k2")) {
+		t.Errorf("expected a previous version to verify")
+	}
+	if v.VerifyAny(verify("k1")) {
+		t.Errorf("expected a dropped version not to verify")
+	}
+}
+
+func TestVersionsNoPrevious(t *testing.T) {
+	v := NewVersions(Obscure([]byte("k1")), 0)
+	v.Rotate(Obscure([]byte("k2")))
+	if got := v.All(); len(got) != 1 || got[0] != Obscure([]byte("k2")) {
+		t.Errorf("expected only the current version to be kept")
+	}
+}
@@ -0,0 +1,111 @@
+package camo
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WatchedSecret is a Secret loaded from a file that is reloaded when the file
+// changes, such as a Kubernetes secret volume that is updated under a running
+// pod. Changes are detected by polling the file, which also works for the
+// symlink swaps that Kubernetes uses to update volumes atomically.
+//
+// It is safe for concurrent use.
+type WatchedSecret struct {
+	path string
+	opts []Option
+
+	current atomic.Pointer[Secret[[]byte]]
+	err     atomic.Pointer[error]
+
+	stat os.FileInfo
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchFile loads the file at path like FromFile, with the given options, and
+// checks it for changes every interval until Close is called. It returns an
+// error if the initial load fails.
+func WatchFile(path string, interval time.Duration, opts ...Option) (*WatchedSecret, error) {
+	w := &WatchedSecret{
+		path: path,
+		opts: opts,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+	go w.poll(interval)
+	return w, nil
+}
+
+// Load returns the most recently loaded content of the file.
+func (w *WatchedSecret) Load() Secret[[]byte] {
+	return *w.current.Load()
+}
+
+// Err returns the error from the most recent attempt to reload the file, or
+// nil if it succeeded. Load keeps returning the last content that was loaded
+// while reloading fails.
+func (w *WatchedSecret) Err() error {
+	if err := w.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Close stops watching the file.
+func (w *WatchedSecret) Close() error {
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+func (w *WatchedSecret) poll(interval time.Duration) {
+	defer close(w.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			if w.changed() {
+				err := w.reload()
+				w.err.Store(&err)
+			}
+		}
+	}
+}
+
+// changed reports if the file might have changed since it was last loaded.
+func (w *WatchedSecret) changed() bool {
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		// Let reload report the error.
+		return true
+	}
+	return !os.SameFile(fi, w.stat) || !fi.ModTime().Equal(w.stat.ModTime()) || fi.Size() != w.stat.Size()
+}
+
+// reload is only called by one goroutine at a time.
+func (w *WatchedSecret) reload() error {
+	fi, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	s, err := FromFile(w.path, w.opts...)
+	if err != nil {
+		return err
+	}
+	w.stat = fi
+	if cur := w.current.Load(); cur == nil || *cur != s {
+		w.current.Store(&s)
+	}
+	return nil
+}
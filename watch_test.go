@@ -0,0 +1,57 @@
+package camo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFile(t *testing.T) {
+	path := writeSecretFile(t, "v1\n", 0o600)
+	w, err := WatchFile(path, time.Millisecond, Trimmed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if got := w.Load().Reveal(); !bytes.Equal(got, []byte("v1")) {
+		t.Fatalf("Load() = %q; want %q", got, "v1")
+	}
+
+	// Replace the file the way Kubernetes does, by renaming over it, so the
+	// change is seen even if the modification time doesn't change.
+	tmp := filepath.Join(filepath.Dir(path), "tmp")
+	if err := os.WriteFile(tmp, []byte("v2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return bytes.Equal(w.Load().Reveal(), []byte("v2")) })
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return w.Err() != nil })
+	if got := w.Load().Reveal(); !bytes.Equal(got, []byte("v2")) {
+		t.Errorf("Load() = %q; want the last loaded content", got)
+	}
+}
+
+func TestWatchFileNotExist(t *testing.T) {
+	if _, err := WatchFile(filepath.Join(t.TempDir(), "missing"), time.Second); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
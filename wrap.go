@@ -0,0 +1,168 @@
+package camo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	stringSecretType = reflect.TypeFor[Secret[string]]()
+	bytesSecretType  = reflect.TypeFor[Secret[[]byte]]()
+)
+
+// Wrap protects the plaintext secrets in a struct, such as a config that was
+// loaded by a third-party decoder, right after it is decoded. The argument
+// must be a non-nil pointer to a struct.
+//
+// Wrap looks for string and []byte fields tagged `camo:"secret"`, in v and
+// in the structs it holds directly, through pointers, or in slices and
+// arrays. The content of each such field is moved into a companion Secret
+// field, and the field is cleared. The companion is the field named by the
+// "into" option of the tag, such as `camo:"secret,into=PasswordSecret"`, or
+// otherwise the field with the name of the tagged field followed by
+// "Secret", if there is one. It must have type Secret[string] or
+// Secret[[]byte].
+//
+// A tagged field without a companion has its content registered (see
+// Register), so that it is scrubbed if it was copied elsewhere before Wrap
+// was called, and is then cleared.
+//
+// Byte slices are wiped when they are cleared. Unexported fields are
+// ignored. Wrapping a struct again leaves the companions of cleared fields
+// as they are.
+func Wrap(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("camo: Wrap requires a non-nil pointer to a struct, not %T", v)
+	}
+	return wrapValue(rv, make(map[uintptr]bool))
+}
+
+// wrapValue wraps the secrets in v, keeping track of the pointers that have
+// been visited to handle cycles.
+func wrapValue(v reflect.Value, seen map[uintptr]bool) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		return wrapValue(v.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		if !hasStructs(v.Type().Elem()) {
+			return nil
+		}
+		for i := range v.Len() {
+			if err := wrapValue(v.Index(i), seen); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		return wrapStruct(v, seen)
+	}
+	return nil
+}
+
+// hasStructs reports if values of type t can hold structs to be wrapped.
+func hasStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func wrapStruct(v reflect.Value, seen map[uintptr]bool) error {
+	t := v.Type()
+	if t == stringSecretType || t == bytesSecretType {
+		return nil
+	}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup("camo")
+		if !ok {
+			if err := wrapValue(v.Field(i), seen); err != nil {
+				return err
+			}
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name != "secret" {
+			return fmt.Errorf("camo: field %s.%s has invalid tag %q", t, sf.Name, tag)
+		}
+		if sf.Type.Kind() != reflect.String && sf.Type != reflect.TypeFor[[]byte]() {
+			return fmt.Errorf("camo: field %s.%s tagged as secret has type %s, not string or []byte", t, sf.Name, sf.Type)
+		}
+		into := sf.Name + "Secret"
+		explicit := false
+		for opt := range strings.SplitSeq(opts, ",") {
+			if name, ok := strings.CutPrefix(opt, "into="); ok {
+				into, explicit = name, true
+			} else if opt != "" {
+				return fmt.Errorf("camo: field %s.%s has invalid tag option %q", t, sf.Name, opt)
+			}
+		}
+		companion, ok := t.FieldByName(into)
+		if !ok && explicit {
+			return fmt.Errorf("camo: field %s.%s names missing field %s", t, sf.Name, into)
+		}
+		if ok && companion.Type != stringSecretType && companion.Type != bytesSecretType {
+			if explicit {
+				return fmt.Errorf("camo: field %s.%s has type %s, not a Secret", t, into, companion.Type)
+			}
+			ok = false
+		}
+		field := v.Field(i)
+		if ok {
+			// The companion may be unexported, or promoted through a nil
+			// embedded pointer.
+			dst, err := v.FieldByIndexErr(companion.Index)
+			if err != nil || !dst.CanSet() {
+				return fmt.Errorf("camo: field %s.%s can't be set, as it is unexported or promoted through a nil pointer", t, into)
+			}
+			if field.Len() == 0 && dst.Interface().(interface{ Valid() bool }).Valid() {
+				// Already wrapped.
+				continue
+			}
+			obscureInto(dst, field)
+		} else {
+			registerField(field)
+		}
+		clearField(field)
+	}
+	return nil
+}
+
+// obscureInto stores the content of field in the Secret dst.
+func obscureInto(dst, field reflect.Value) {
+	var s Secret[string]
+	if field.Kind() == reflect.String {
+		s = Obscure(field.String())
+	} else {
+		s = convert[string](Obscure(field.Bytes()))
+	}
+	if dst.Type() == bytesSecretType {
+		dst.Set(reflect.ValueOf(convert[[]byte](s)))
+	} else {
+		dst.Set(reflect.ValueOf(s))
+	}
+}
+
+func registerField(field reflect.Value) {
+	if field.Kind() == reflect.String {
+		Register(Obscure(field.String()))
+	} else {
+		Register(Obscure(field.Bytes()))
+	}
+}
+
+func clearField(field reflect.Value) {
+	if field.Kind() == reflect.Slice {
+		wipe(field.Bytes())
+	}
+	field.SetZero()
+}
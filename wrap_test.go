@@ -0,0 +1,115 @@
+package camo
+
+import (
+	"strings"
+	"testing"
+)
+
+type wrapDB struct {
+	Password       string `camo:"secret"`
+	PasswordSecret Secret[string]
+}
+
+type wrapConfig struct {
+	Host   string
+	APIKey []byte `camo:"secret,into=Key"`
+	Key    Secret[[]byte]
+	Token  string `camo:"secret"`
+	DB     *wrapDB
+	Shards []wrapDB
+
+	hidden string `camo:"secret"`
+}
+
+func TestWrap(t *testing.T) {
+	key := []byte("api-key")
+	c := &wrapConfig{
+		Host:   "example.com",
+		APIKey: key,
+		Token:  "camo-test-wrap-token",
+		DB:     &wrapDB{Password: "hunter2"},
+		Shards: []wrapDB{{Password: "shard-password"}},
+		hidden: "hidden",
+	}
+	if err := Wrap(c); err != nil {
+		t.Fatal(err)
+	}
+	defer Unregister(Obscure("camo-test-wrap-token"))
+
+	if c.APIKey != nil || c.Token != "" || c.DB.Password != "" || c.Shards[0].Password != "" {
+		t.Errorf("expected tagged fields to be cleared: %+v", c)
+	}
+	if string(key) != "\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("expected the byte slice to be wiped, got %q", key)
+	}
+	if got := c.Key.Reveal(); string(got) != "api-key" {
+		t.Errorf("Key = %q; want %q", got, "api-key")
+	}
+	if c.DB.PasswordSecret != Obscure("hunter2") || c.Shards[0].PasswordSecret != Obscure("shard-password") {
+		t.Errorf("expected passwords to be moved into companion fields")
+	}
+	if got := Scrub("token camo-test-wrap-token"); strings.Contains(got, "camo-test-wrap-token") {
+		t.Errorf("expected a field without a companion to be registered, got %q", got)
+	}
+	if c.Host != "example.com" || c.hidden != "hidden" {
+		t.Errorf("expected other fields to be left alone: %+v", c)
+	}
+}
+
+func TestWrapErrors(t *testing.T) {
+	var notStruct string
+	for name, v := range map[string]any{
+		"nil":         nil,
+		"not pointer": wrapDB{},
+		"not struct":  &notStruct,
+		"bad type": &struct {
+			Port int `camo:"secret"`
+		}{},
+		"bad tag": &struct {
+			Password string `camo:"public"`
+		}{},
+		"missing companion": &struct {
+			Password string `camo:"secret,into=Missing"`
+		}{},
+		"bad companion": &struct {
+			Password string `camo:"secret,into=Other"`
+			Other    string
+		}{},
+		"unexported companion": &struct {
+			Password string `camo:"secret,into=password"`
+			password Secret[string]
+		}{},
+	} {
+		if err := Wrap(v); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWrapCycle(t *testing.T) {
+	type node struct {
+		Password       string `camo:"secret"`
+		PasswordSecret Secret[string]
+		Next           *node
+	}
+	n := &node{Password: "hunter2"}
+	n.Next = n
+	if err := Wrap(n); err != nil {
+		t.Fatal(err)
+	}
+	if n.PasswordSecret != Obscure("hunter2") {
+		t.Errorf("expected the password to be moved")
+	}
+}
+
+func TestWrapTwice(t *testing.T) {
+	db := &wrapDB{Password: "hunter2"}
+	for range 2 {
+		if err := Wrap(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if db.PasswordSecret != Obscure("hunter2") {
+		t.Errorf("expected wrapping again to keep the companion")
+	}
+}